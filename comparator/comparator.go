@@ -2,6 +2,8 @@
 // comparing values.
 package comparator
 
+import "cmp"
+
 // Comparator is a function type for comparing two values.
 //
 // It must return
@@ -17,3 +19,112 @@ func Reverse[T any](cmp Comparator[T]) Comparator[T] {
 		return cmp(b, a)
 	}
 }
+
+// Natural returns the comparator of the natural ordering of T, which is
+// [cmp.Compare].
+func Natural[T cmp.Ordered]() Comparator[T] {
+	return cmp.Compare[T]
+}
+
+// By returns a comparator that compares values by the keys extracted
+// with key, in their natural ordering.
+func By[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ThenBy returns a comparator that compares values with first, and then
+// with next if first finds them equal.
+func ThenBy[T any](first, next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if c := first(a, b); c != 0 {
+			return c
+		}
+		return next(a, b)
+	}
+}
+
+// Chain returns a comparator that compares values with each of cmps in
+// turn, until one finds them different. If cmps is empty, all values are
+// equal.
+func Chain[T any](cmps ...Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		for _, compare := range cmps {
+			if c := compare(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// NilsFirst returns a comparator of pointers that orders nil before any
+// other pointer, and compares the values pointed to with cmp otherwise.
+func NilsFirst[T any](cmp Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		}
+		return cmp(*a, *b)
+	}
+}
+
+// NilsLast returns a comparator of pointers that orders nil after any
+// other pointer, and compares the values pointed to with cmp otherwise.
+func NilsLast[T any](cmp Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		case b == nil:
+			return -1
+		}
+		return cmp(*a, *b)
+	}
+}
+
+// FromLess returns a comparator from a less function, which must report
+// whether a is less than b and be a strict weak ordering.
+func FromLess[T any](less func(a, b T) bool) Comparator[T] {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	}
+}
+
+// Min returns the minimum of first and rest according to cmp. If several
+// values are minimal, it returns the first of them.
+func Min[T any](cmp Comparator[T], first T, rest ...T) T {
+	m := first
+	for _, v := range rest {
+		if cmp(v, m) < 0 {
+			m = v
+		}
+	}
+	return m
+}
+
+// Max returns the maximum of first and rest according to cmp. If several
+// values are maximal, it returns the first of them.
+func Max[T any](cmp Comparator[T], first T, rest ...T) T {
+	m := first
+	for _, v := range rest {
+		if cmp(v, m) > 0 {
+			m = v
+		}
+	}
+	return m
+}
@@ -2,6 +2,7 @@ package comparator_test
 
 import (
 	"cmp"
+	"slices"
 	"testing"
 
 	"github.com/linhns/gocontainers/comparator"
@@ -15,3 +16,127 @@ func TestReverse(t *testing.T) {
 	assert.Equal(t, 1, cmpr(5, 6))
 	assert.Equal(t, -1, cmpr(6, 5))
 }
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestNaturalAndBy(t *testing.T) {
+	assert.Equal(t, -1, comparator.Natural[int]()(1, 2))
+	assert.Equal(t, 1, comparator.Natural[string]()("b", "a"))
+
+	byAge := comparator.By(func(p person) int { return p.age })
+	assert.Equal(t, -1, byAge(person{"b", 20}, person{"a", 30}))
+	assert.Equal(t, 0, byAge(person{"b", 20}, person{"a", 20}))
+}
+
+func TestThenByAndChain(t *testing.T) {
+	byAge := comparator.By(func(p person) int { return p.age })
+	byName := comparator.By(func(p person) string { return p.name })
+	people := []person{{"carol", 30}, {"bob", 20}, {"alice", 30}, {"dave", 20}}
+
+	sorted := slices.Clone(people)
+	slices.SortFunc(sorted, comparator.ThenBy(byAge, byName))
+	assert.Equal(t, []person{{"bob", 20}, {"dave", 20}, {"alice", 30}, {"carol", 30}}, sorted)
+
+	slices.SortFunc(sorted, comparator.Chain(comparator.Reverse(byAge), byName))
+	assert.Equal(t, []person{{"alice", 30}, {"carol", 30}, {"bob", 20}, {"dave", 20}}, sorted)
+
+	assert.Equal(t, 0, comparator.Chain[person]()(people[0], people[1]))
+}
+
+func TestNils(t *testing.T) {
+	one, two := 1, 2
+	vals := []*int{&two, nil, &one}
+
+	slices.SortFunc(vals, comparator.NilsFirst(cmp.Compare[int]))
+	assert.Equal(t, []*int{nil, &one, &two}, vals)
+
+	slices.SortFunc(vals, comparator.NilsLast(cmp.Compare[int]))
+	assert.Equal(t, []*int{&one, &two, nil}, vals)
+
+	assert.Equal(t, 0, comparator.NilsFirst(cmp.Compare[int])(nil, nil))
+	assert.Equal(t, 0, comparator.NilsLast(cmp.Compare[int])(nil, nil))
+}
+
+func TestFromLess(t *testing.T) {
+	c := comparator.FromLess(func(a, b int) bool { return a < b })
+	assert.Equal(t, -1, c(1, 2))
+	assert.Equal(t, 0, c(2, 2))
+	assert.Equal(t, 1, c(3, 2))
+}
+
+func TestMinMax(t *testing.T) {
+	byAge := comparator.By(func(p person) int { return p.age })
+	people := []person{{"carol", 30}, {"bob", 20}, {"alice", 30}, {"dave", 20}}
+
+	assert.Equal(t, person{"bob", 20}, comparator.Min(byAge, people[0], people[1:]...))
+	assert.Equal(t, person{"carol", 30}, comparator.Max(byAge, people[0], people[1:]...))
+	assert.Equal(t, 5, comparator.Min(cmp.Compare[int], 5))
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	assert.Equal(t, 0, comparator.CaseInsensitive("Hello", "hELLO"))
+	assert.Equal(t, -1, comparator.CaseInsensitive("apple", "Banana"))
+	assert.Equal(t, 1, comparator.CaseInsensitive("Zebra", "apple"))
+	assert.Equal(t, -1, comparator.CaseInsensitive("abc", "ABCD"))
+	assert.Equal(t, 0, comparator.CaseInsensitive("ÄÖÜ", "äöü"))
+}
+
+func TestNaturalString(t *testing.T) {
+	files := []string{"file10.txt", "file2.txt", "File1.txt", "file1.txt", "file02.txt", "file", "file2", "10", "9"}
+	slices.SortFunc(files, comparator.NaturalString)
+	assert.Equal(t, []string{"9", "10", "File1.txt", "file", "file1.txt", "file2", "file02.txt", "file2.txt", "file10.txt"}, files)
+
+	assert.Equal(t, 0, comparator.NaturalString("a12b", "a12b"))
+	assert.Equal(t, -1, comparator.NaturalString("v1.9", "v1.10"))
+	assert.Equal(t, 1, comparator.NaturalString("x100000000000000000000000", "x99999999999999999999999"))
+}
+
+func TestValidate(t *testing.T) {
+	ints := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	assert.NoError(t, comparator.Validate(cmp.Compare[int], ints))
+	assert.NoError(t, comparator.Validate(comparator.Reverse(cmp.Compare[int]), ints))
+
+	strs := []string{"a1", "a01", "a10", "A2", "b", "", "1", "01", "x9y", "x10y", "x09y"}
+	assert.NoError(t, comparator.Validate(comparator.NaturalString, strs))
+	assert.NoError(t, comparator.Validate(comparator.CaseInsensitive, strs))
+
+	lessOrEqual := func(a, b int) int {
+		if a <= b {
+			return -1
+		}
+		return 1
+	}
+	assert.ErrorContains(t, comparator.Validate(lessOrEqual, ints), "not reflexive")
+
+	asymmetric := func(a, b int) int {
+		if a == b {
+			return 0
+		}
+		return -1
+	}
+	assert.ErrorContains(t, comparator.Validate(asymmetric, ints), "not antisymmetric")
+
+	// Rock, paper, scissors.
+	cyclic := func(a, b int) int {
+		switch (b - a + 3) % 3 {
+		case 1:
+			return -1
+		case 2:
+			return 1
+		}
+		return 0
+	}
+	assert.ErrorContains(t, comparator.Validate(cyclic, []int{0, 1, 2}), "not transitive")
+
+	// Equality must be transitive too.
+	close := func(a, b int) int {
+		if a-b <= 1 && b-a <= 1 {
+			return 0
+		}
+		return cmp.Compare(a, b)
+	}
+	assert.ErrorContains(t, comparator.Validate(close, []int{1, 2, 3}), "not transitive")
+}
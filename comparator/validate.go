@@ -0,0 +1,56 @@
+package comparator
+
+import "fmt"
+
+// Validate checks that cmp is consistent on samples: every sample equals
+// itself, swapping arguments flips the sign of the result, and both
+// "less than" and "equal" are transitive. It returns an error describing
+// the first violation found, or nil.
+//
+// Validate takes O(n³) time for n samples, so it is meant for tests with
+// small sample sets.
+func Validate[T any](cmp Comparator[T], samples []T) error {
+	for _, a := range samples {
+		if c := cmp(a, a); c != 0 {
+			return fmt.Errorf("comparator: not reflexive: cmp(%v, %v) = %d", a, a, c)
+		}
+	}
+	for _, a := range samples {
+		for _, b := range samples {
+			if ab, ba := sign(cmp(a, b)), sign(cmp(b, a)); ab != -ba {
+				return fmt.Errorf("comparator: not antisymmetric: cmp(%v, %v) = %d, cmp(%v, %v) = %d",
+					a, b, cmp(a, b), b, a, cmp(b, a))
+			}
+		}
+	}
+	for _, a := range samples {
+		for _, b := range samples {
+			ab := sign(cmp(a, b))
+			if ab > 0 {
+				continue
+			}
+			for _, c := range samples {
+				bc := sign(cmp(b, c))
+				if bc > 0 {
+					continue
+				}
+				// a <= b <= c, so a < c unless a == b == c.
+				if ac := sign(cmp(a, c)); ac != min(ab, bc) {
+					return fmt.Errorf("comparator: not transitive: cmp(%v, %v) = %d, cmp(%v, %v) = %d, cmp(%v, %v) = %d",
+						a, b, cmp(a, b), b, c, cmp(b, c), a, c, cmp(a, c))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
@@ -4,7 +4,11 @@ package hashmap
 
 import (
 	"iter"
+	"maps"
 	"sync"
+
+	"github.com/linhns/gocontainers/container"
+	seqhashmap "github.com/linhns/gocontainers/hashmap"
 )
 
 // HashMap is a generic hash table (map).
@@ -13,6 +17,8 @@ type HashMap[K comparable, V any] struct {
 	data map[K]V
 }
 
+var _ container.Map[int, int] = (*HashMap[int, int])(nil)
+
 // New creates and initialize a new [HashMap].
 func New[K comparable, V any]() *HashMap[K, V] {
 	return &HashMap[K, V]{
@@ -92,7 +98,9 @@ func (m *HashMap[K, V]) Empty() bool {
 // The iteration order is unspecified and not guaranteed
 // to remain the same between calls.
 //
-// The iterator must not modify the map to avoid deadlock.
+// The map is read-locked during the iteration, so the loop body must not
+// modify it to avoid deadlock. To modify the map while iterating, iterate
+// over a [HashMap.Snapshot] instead.
 func (m *HashMap[K, V]) Keys() iter.Seq[K] {
 	return func(yield func(K) bool) {
 		m.mu.RLock()
@@ -110,7 +118,9 @@ func (m *HashMap[K, V]) Keys() iter.Seq[K] {
 // The iteration order is unspecified and not guaranteed
 // to remain the same between calls.
 //
-// The iterator must not modify the map to avoid deadlock.
+// The map is read-locked during the iteration, so the loop body must not
+// modify it to avoid deadlock. To modify the map while iterating, iterate
+// over a [HashMap.Snapshot] instead.
 func (m *HashMap[K, V]) Values() iter.Seq[V] {
 	return func(yield func(V) bool) {
 		m.mu.RLock()
@@ -128,7 +138,9 @@ func (m *HashMap[K, V]) Values() iter.Seq[V] {
 // The iteration order is unspecified and not guaranteed
 // to remain the same between calls.
 //
-// The iterator must not modify the map to avoid deadlock.
+// The map is read-locked during the iteration, so the loop body must not
+// modify it to avoid deadlock. To modify the map while iterating, iterate
+// over a [HashMap.Snapshot] instead.
 func (m *HashMap[K, V]) All() iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
 		m.mu.RLock()
@@ -142,6 +154,34 @@ func (m *HashMap[K, V]) All() iter.Seq2[K, V] {
 	}
 }
 
+// Clone returns a copy of the map.
+func (m *HashMap[K, V]) Clone() *HashMap[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return &HashMap[K, V]{
+		data: maps.Clone(m.data),
+	}
+}
+
+// Snapshot returns a copy of the key-value pairs of the map as a map
+// that is not safe for concurrent use.
+//
+// Iterating over a snapshot does not hold any lock, so the loop body
+// may freely modify the original map:
+//
+//	for k, v := range m.Snapshot().All() {
+//		if v == 0 {
+//			m.Remove(k)
+//		}
+//	}
+func (m *HashMap[K, V]) Snapshot() *seqhashmap.HashMap[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return seqhashmap.Collect(maps.All(m.data))
+}
+
 // Collect collects key-value pairs from an iterator and returns a new map.
 func Collect[K comparable, V any](seq iter.Seq2[K, V]) *HashMap[K, V] {
 	m := New[K, V]()
@@ -2,11 +2,15 @@ package hashmap_test
 
 import (
 	"maps"
+	"math/rand/v2"
 	"slices"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/linhns/gocontainers/concurrent/hashmap"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/internal/lincheck"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -137,3 +141,120 @@ func TestMapIteratorConcurrent(t *testing.T) {
 	}()
 	close(start)
 }
+
+func TestConformance(t *testing.T) {
+	containertest.TestMap(t, hashmap.New[int, string])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestMapModel(t, hashmap.New[int, string])
+}
+
+func FuzzHashMap(f *testing.F) {
+	containertest.FuzzMap(f, hashmap.New[int, string])
+}
+
+type mapOp int
+
+const (
+	opInsert mapOp = iota
+	opRemove
+	opGet
+)
+
+type mapInput struct {
+	op    mapOp
+	key   int
+	value int
+}
+
+type mapOutput struct {
+	value int
+	ok    bool
+}
+
+var mapModel = lincheck.Model[map[int]int, mapInput, mapOutput]{
+	Init: func() map[int]int { return map[int]int{} },
+	Step: func(state map[int]int, in mapInput, out mapOutput) (bool, map[int]int) {
+		switch in.op {
+		case opInsert:
+			next := maps.Clone(state)
+			next[in.key] = in.value
+			return true, next
+		case opRemove:
+			next := maps.Clone(state)
+			delete(next, in.key)
+			return true, next
+		default:
+			v, ok := state[in.key]
+			return out == mapOutput{v, ok}, state
+		}
+	},
+}
+
+func TestMapLinearizable(t *testing.T) {
+	for range 50 {
+		m := hashmap.New[int, int]()
+		var rec lincheck.Recorder[mapInput, mapOutput]
+		lincheck.Run(4, 8, func(client, i int) {
+			in := mapInput{op: mapOp(rand.IntN(3)), key: rand.IntN(3)}
+			switch in.op {
+			case opInsert:
+				in.value = client*100 + i
+				rec.Record(client, in, func() mapOutput {
+					m.Insert(in.key, in.value)
+					return mapOutput{}
+				})
+			case opRemove:
+				rec.Record(client, in, func() mapOutput {
+					m.Remove(in.key)
+					return mapOutput{}
+				})
+			case opGet:
+				rec.Record(client, in, func() mapOutput {
+					v, ok := m.Get(in.key)
+					return mapOutput{v, ok}
+				})
+			}
+		})
+		assert.True(t, lincheck.Check(mapModel, rec.History()))
+	}
+}
+
+func TestMapClone(t *testing.T) {
+	m := hashmap.New[string, int]()
+	m.Insert("one", 1)
+
+	c := m.Clone()
+	c.Insert("two", 2)
+	m.Remove("one")
+
+	assert.True(t, m.Empty())
+	assert.Equal(t, map[string]int{"one": 1, "two": 2}, maps.Collect(c.All()))
+}
+
+func TestMapSnapshotMutateWhileIterating(t *testing.T) {
+	m := hashmap.New[int, int]()
+	for i := range 10 {
+		m.Insert(i, i%2)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for k, v := range m.Snapshot().All() {
+			if v == 0 {
+				m.Remove(k)
+			} else {
+				m.Insert(k+100, v)
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("deadlock")
+	}
+
+	assert.ElementsMatch(t, []int{1, 3, 5, 7, 9, 101, 103, 105, 107, 109}, slices.Collect(m.Keys()))
+}
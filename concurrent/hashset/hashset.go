@@ -3,8 +3,14 @@
 package hashset
 
 import (
+	"cmp"
 	"iter"
+	"maps"
+	"slices"
 	"sync"
+	"unsafe"
+
+	"github.com/linhns/gocontainers/container"
 )
 
 // HashSet holds a set of unique elements
@@ -13,6 +19,8 @@ type HashSet[K comparable] struct {
 	data map[K]struct{}
 }
 
+var _ container.Set[int] = (*HashSet[int])(nil)
+
 // New creates and initialize a new [HashSet]
 func New[K comparable]() *HashSet[K] {
 	s := &HashSet[K]{
@@ -96,11 +104,11 @@ func Collect[K comparable](seq iter.Seq[K]) *HashSet[K] {
 
 // Equal reports whether two sets contain the same elements
 func Equal[K comparable](s1, s2 *HashSet[K]) bool {
-	s1.mu.RLock()
-	defer s1.mu.RUnlock()
-
-	s2.mu.RLock()
-	defer s2.mu.RUnlock()
+	if s1 == s2 {
+		return true
+	}
+	unlock := rlockAll(s1, s2)
+	defer unlock()
 
 	if len(s1.data) != len(s2.data) {
 		return false
@@ -113,35 +121,66 @@ func Equal[K comparable](s1, s2 *HashSet[K]) bool {
 	return true
 }
 
-// Union returns a new set that contains all elements from two sets
-func Union[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
-	s1.mu.RLock()
-	defer s1.mu.RUnlock()
+// Union returns a new set that contains all elements from the given sets
+func Union[K comparable](sets ...*HashSet[K]) *HashSet[K] {
+	unlock := rlockAll(sets...)
+	defer unlock()
 
-	s2.mu.RLock()
-	defer s2.mu.RUnlock()
+	n := 0
+	for _, s := range sets {
+		n = max(n, len(s.data))
+	}
+	result := &HashSet[K]{data: make(map[K]struct{}, n)}
+	for _, s := range sets {
+		for v := range s.data {
+			result.data[v] = struct{}{}
+		}
+	}
+	return result
+}
 
+// Intersection returns a new set that contains elements common to all
+// the given sets. The intersection of no sets is empty.
+//
+// The smallest set is iterated first, so the cost is proportional to its
+// size rather than to the size of the largest set.
+func Intersection[K comparable](sets ...*HashSet[K]) *HashSet[K] {
 	result := New[K]()
-	for v := range s1.data {
-		result.data[v] = struct{}{}
+	if len(sets) == 0 {
+		return result
 	}
-	for v := range s2.data {
-		result.data[v] = struct{}{}
+	unlock := rlockAll(sets...)
+	defer unlock()
+
+	smallest := slices.MinFunc(sets, func(a, b *HashSet[K]) int {
+		return cmp.Compare(len(a.data), len(b.data))
+	})
+	for v := range smallest.data {
+		if containedInAll(v, sets) {
+			result.data[v] = struct{}{}
+		}
 	}
 	return result
 }
 
-// Intersection returns a new set that contains common elements from two sets
-func Intersection[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
-	s1.mu.RLock()
-	defer s1.mu.RUnlock()
+func containedInAll[K comparable](v K, sets []*HashSet[K]) bool {
+	for _, s := range sets {
+		if _, ok := s.data[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
 
-	s2.mu.RLock()
-	defer s2.mu.RUnlock()
+// Difference returns a new set that contains elements
+// that are in the first set but not in the second set
+func Difference[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
+	unlock := rlockAll(s1, s2)
+	defer unlock()
 
 	result := New[K]()
 	for v := range s1.data {
-		if _, ok := s2.data[v]; ok {
+		if _, ok := s2.data[v]; !ok {
 			result.data[v] = struct{}{}
 		}
 	}
@@ -149,14 +188,11 @@ func Intersection[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
 	return result
 }
 
-// Difference returns a new set that contains elements
-// that are in the first set but not in the second set
-func Difference[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
-	s1.mu.RLock()
-	defer s1.mu.RUnlock()
-
-	s2.mu.RLock()
-	defer s2.mu.RUnlock()
+// SymmetricDifference returns a new set that contains elements
+// that are in exactly one of the two sets
+func SymmetricDifference[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
+	unlock := rlockAll(s1, s2)
+	defer unlock()
 
 	result := New[K]()
 	for v := range s1.data {
@@ -164,6 +200,206 @@ func Difference[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
 			result.data[v] = struct{}{}
 		}
 	}
+	for v := range s2.data {
+		if _, ok := s1.data[v]; !ok {
+			result.data[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// IsSubset reports whether every element of s is in other
+func (s *HashSet[K]) IsSubset(other *HashSet[K]) bool {
+	if s == other {
+		return true
+	}
+	unlock := rlockAll(s, other)
+	defer unlock()
+
+	if len(s.data) > len(other.data) {
+		return false
+	}
+	for v := range s.data {
+		if _, ok := other.data[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether every element of other is in s
+func (s *HashSet[K]) IsSuperset(other *HashSet[K]) bool {
+	return other.IsSubset(s)
+}
+
+// IsDisjoint reports whether s and other have no elements in common
+func (s *HashSet[K]) IsDisjoint(other *HashSet[K]) bool {
+	unlock := rlockAll(s, other)
+	defer unlock()
+
+	small, large := s, other
+	if len(small.data) > len(large.data) {
+		small, large = large, small
+	}
+	for v := range small.data {
+		if _, ok := large.data[v]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionWith adds all elements of others to s
+func (s *HashSet[K]) UnionWith(others ...*HashSet[K]) {
+	others, _, unlock := lockWith(s, others)
+	defer unlock()
+
+	for _, o := range others {
+		for v := range o.data {
+			s.data[v] = struct{}{}
+		}
+	}
+}
+
+// IntersectWith removes the elements of s that are not in all of others
+func (s *HashSet[K]) IntersectWith(others ...*HashSet[K]) {
+	others, _, unlock := lockWith(s, others)
+	defer unlock()
+
+	for v := range s.data {
+		if !containedInAll(v, others) {
+			delete(s.data, v)
+		}
+	}
+}
+
+// DifferenceWith removes the elements of s that are in any of others
+func (s *HashSet[K]) DifferenceWith(others ...*HashSet[K]) {
+	others, aliased, unlock := lockWith(s, others)
+	defer unlock()
+
+	if aliased {
+		clear(s.data)
+		return
+	}
+	for _, o := range others {
+		for v := range o.data {
+			delete(s.data, v)
+		}
+	}
+}
 
+// Filter returns a new set that contains the elements of s
+// for which pred returns true.
+//
+// pred is called on a snapshot of the elements without holding the lock,
+// so it may access the set.
+func (s *HashSet[K]) Filter(pred func(K) bool) *HashSet[K] {
+	result := New[K]()
+	for _, v := range s.snapshot() {
+		if pred(v) {
+			result.data[v] = struct{}{}
+		}
+	}
 	return result
 }
+
+// Partition returns two new sets that contain the elements of s
+// for which pred returns true and false, respectively.
+//
+// pred is called on a snapshot of the elements without holding the lock,
+// so it may access the set.
+func (s *HashSet[K]) Partition(pred func(K) bool) (in, out *HashSet[K]) {
+	in, out = New[K](), New[K]()
+	for _, v := range s.snapshot() {
+		if pred(v) {
+			in.data[v] = struct{}{}
+		} else {
+			out.data[v] = struct{}{}
+		}
+	}
+	return in, out
+}
+
+func (s *HashSet[K]) snapshot() []K {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.AppendSeq(make([]K, 0, len(s.data)), maps.Keys(s.data))
+}
+
+// Clone returns a copy of the set
+func (s *HashSet[K]) Clone() *HashSet[K] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &HashSet[K]{data: maps.Clone(s.data)}
+}
+
+// AddAll adds all elements from an iterator to the set.
+//
+// The elements are collected before the lock is taken, so seq may
+// access the set, e.g. s.AddAll(other.All()) with other == s.
+func (s *HashSet[K]) AddAll(seq iter.Seq[K]) {
+	vals := slices.Collect(seq)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, v := range vals {
+		s.data[v] = struct{}{}
+	}
+}
+
+// rlockAll read-locks each distinct set among sets exactly once, in order
+// of their addresses, and returns a function that unlocks them.
+//
+// Acquiring the locks in a global order prevents the deadlock in which
+// goroutines each hold one read lock and wait for another while writers
+// are queued on both, and locking each set once prevents a goroutine from
+// blocking on its own read lock behind a queued writer.
+func rlockAll[K comparable](sets ...*HashSet[K]) (unlock func()) {
+	sets = slices.Clone(sets)
+	slices.SortFunc(sets, func(a, b *HashSet[K]) int {
+		return cmp.Compare(uintptr(unsafe.Pointer(a)), uintptr(unsafe.Pointer(b)))
+	})
+	sets = slices.Compact(sets)
+	for _, s := range sets {
+		s.mu.RLock()
+	}
+	return func() {
+		for i := len(sets) - 1; i >= 0; i-- {
+			sets[i].mu.RUnlock()
+		}
+	}
+}
+
+// lockWith write-locks s and read-locks each distinct set among others
+// other than s, all in order of their addresses, like [rlockAll].
+//
+// It returns the distinct sets among others other than s, whether s was
+// among others, and a function that unlocks all the sets.
+func lockWith[K comparable](s *HashSet[K], others []*HashSet[K]) (rest []*HashSet[K], aliased bool, unlock func()) {
+	sets := append(slices.Clone(others), s)
+	slices.SortFunc(sets, func(a, b *HashSet[K]) int {
+		return cmp.Compare(uintptr(unsafe.Pointer(a)), uintptr(unsafe.Pointer(b)))
+	})
+	sets = slices.Compact(sets)
+	for _, x := range sets {
+		if x == s {
+			x.mu.Lock()
+		} else {
+			x.mu.RLock()
+			rest = append(rest, x)
+		}
+	}
+	return rest, slices.Contains(others, s), func() {
+		for i := len(sets) - 1; i >= 0; i-- {
+			if sets[i] == s {
+				sets[i].mu.Unlock()
+			} else {
+				sets[i].mu.RUnlock()
+			}
+		}
+	}
+}
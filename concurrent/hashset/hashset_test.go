@@ -1,6 +1,8 @@
 package hashset_test
 
 import (
+	"maps"
+	"math/rand/v2"
 	"slices"
 	"sync"
 	"testing"
@@ -8,6 +10,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/linhns/gocontainers/concurrent/hashset"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/internal/lincheck"
+	"github.com/linhns/gocontainers/internal/testutil"
 )
 
 func TestSetBasicOperations(t *testing.T) {
@@ -123,3 +128,212 @@ func TestSetConcurrent(t *testing.T) {
 	close(start)
 	wg.Wait()
 }
+
+func TestSetAlgebra(t *testing.T) {
+	t.Parallel()
+
+	s1 := hashset.Collect(slices.Values([]int{1, 2, 4, 5}))
+	s2 := hashset.Collect(slices.Values([]int{1, 2, 3, 4}))
+	s3 := hashset.Collect(slices.Values([]int{2, 4, 6}))
+
+	symmetric := hashset.Collect(slices.Values([]int{3, 5}))
+	assert.True(t, hashset.Equal(hashset.SymmetricDifference(s1, s2), symmetric))
+
+	union := hashset.Collect(slices.Values([]int{1, 2, 3, 4, 5, 6}))
+	assert.True(t, hashset.Equal(hashset.Union(s1, s2, s3), union))
+	assert.True(t, hashset.Union[int]().Empty())
+
+	intersection := hashset.Collect(slices.Values([]int{2, 4}))
+	assert.True(t, hashset.Equal(hashset.Intersection(s1, s2, s3), intersection))
+	assert.True(t, hashset.Equal(hashset.Intersection(s1), s1))
+	assert.True(t, hashset.Intersection[int]().Empty())
+
+	assert.True(t, intersection.IsSubset(s1))
+	assert.True(t, s1.IsSubset(s1))
+	assert.False(t, s1.IsSubset(intersection))
+	assert.False(t, s3.IsSubset(union.Filter(func(v int) bool { return v != 6 })))
+	assert.True(t, s1.IsSuperset(intersection))
+	assert.False(t, intersection.IsSuperset(s1))
+
+	assert.False(t, s1.IsDisjoint(s3))
+	assert.True(t, symmetric.IsDisjoint(s3))
+	assert.True(t, hashset.New[int]().IsDisjoint(s1))
+}
+
+func TestSetInPlaceOperations(t *testing.T) {
+	t.Parallel()
+
+	s := hashset.Collect(slices.Values([]int{1, 2}))
+	s.UnionWith(hashset.Collect(slices.Values([]int{2, 3})), hashset.Collect(slices.Values([]int{4})))
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, slices.Collect(s.All()))
+
+	s.IntersectWith(hashset.Collect(slices.Values([]int{1, 2, 3})), hashset.Collect(slices.Values([]int{2, 3, 4})))
+	assert.ElementsMatch(t, []int{2, 3}, slices.Collect(s.All()))
+
+	s.DifferenceWith(hashset.Collect(slices.Values([]int{3})))
+	assert.ElementsMatch(t, []int{2}, slices.Collect(s.All()))
+
+	s.AddAll(slices.Values([]int{5, 6, 5}))
+	assert.ElementsMatch(t, []int{2, 5, 6}, slices.Collect(s.All()))
+
+	s.UnionWith(s)
+	s.IntersectWith(s)
+	assert.Equal(t, 3, s.Len())
+	s.AddAll(s.All())
+	assert.Equal(t, 3, s.Len())
+	s.DifferenceWith(s)
+	assert.True(t, s.Empty())
+}
+
+func TestSetFilterPartitionClone(t *testing.T) {
+	t.Parallel()
+
+	s := hashset.Collect(slices.Values([]int{1, 2, 3, 4, 5}))
+	even := func(v int) bool { return v%2 == 0 }
+
+	assert.ElementsMatch(t, []int{2, 4}, slices.Collect(s.Filter(even).All()))
+
+	in, out := s.Partition(even)
+	assert.ElementsMatch(t, []int{2, 4}, slices.Collect(in.All()))
+	assert.ElementsMatch(t, []int{1, 3, 5}, slices.Collect(out.All()))
+
+	c := s.Clone()
+	c.Add(6)
+	s.Remove(1)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6}, slices.Collect(c.All()))
+	assert.ElementsMatch(t, []int{2, 3, 4, 5}, slices.Collect(s.All()))
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestSet(t, hashset.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestSetModel(t, hashset.New[int])
+}
+
+func FuzzHashSet(f *testing.F) {
+	containertest.FuzzSet(f, hashset.New[int])
+}
+
+type setOp int
+
+const (
+	opAdd setOp = iota
+	opRemove
+	opContains
+)
+
+type setInput struct {
+	op  setOp
+	key int
+}
+
+var setModel = lincheck.Model[map[int]bool, setInput, bool]{
+	Init: func() map[int]bool { return map[int]bool{} },
+	Step: func(state map[int]bool, in setInput, out bool) (bool, map[int]bool) {
+		switch in.op {
+		case opAdd:
+			next := maps.Clone(state)
+			next[in.key] = true
+			return true, next
+		case opRemove:
+			next := maps.Clone(state)
+			delete(next, in.key)
+			return true, next
+		default:
+			return out == state[in.key], state
+		}
+	},
+}
+
+func TestSetLinearizable(t *testing.T) {
+	for range 50 {
+		s := hashset.New[int]()
+		var rec lincheck.Recorder[setInput, bool]
+		lincheck.Run(4, 8, func(client, _ int) {
+			in := setInput{op: setOp(rand.IntN(3)), key: rand.IntN(3)}
+			rec.Record(client, in, func() bool {
+				switch in.op {
+				case opAdd:
+					s.Add(in.key)
+				case opRemove:
+					s.Remove(in.key)
+				default:
+					return s.Contains(in.key)
+				}
+				return false
+			})
+		})
+		assert.True(t, lincheck.Check(setModel, rec.History()))
+	}
+}
+
+func TestSetOperationsWithWriters(t *testing.T) {
+	ops := map[string]func(s1, s2 *hashset.HashSet[int]){
+		"Equal":        func(s1, s2 *hashset.HashSet[int]) { hashset.Equal(s1, s2) },
+		"Union":        func(s1, s2 *hashset.HashSet[int]) { hashset.Union(s1, s2) },
+		"Intersection": func(s1, s2 *hashset.HashSet[int]) { hashset.Intersection(s1, s2) },
+		"Difference":   func(s1, s2 *hashset.HashSet[int]) { hashset.Difference(s1, s2) },
+		"SymmetricDifference": func(s1, s2 *hashset.HashSet[int]) {
+			hashset.SymmetricDifference(s1, s2)
+		},
+		"IsSubset":       func(s1, s2 *hashset.HashSet[int]) { s1.IsSubset(s2) },
+		"IsDisjoint":     func(s1, s2 *hashset.HashSet[int]) { s1.IsDisjoint(s2) },
+		"UnionWith":      func(s1, s2 *hashset.HashSet[int]) { s1.UnionWith(s2, s1) },
+		"IntersectWith":  func(s1, s2 *hashset.HashSet[int]) { s1.IntersectWith(s2, s1) },
+		"DifferenceWith": func(s1, s2 *hashset.HashSet[int]) { s1.DifferenceWith(s2) },
+		"AddAll":         func(s1, s2 *hashset.HashSet[int]) { s1.AddAll(s2.All()) },
+		"Filter": func(s1, s2 *hashset.HashSet[int]) {
+			s1.Filter(s2.Contains)
+		},
+	}
+
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			s1 := hashset.Collect(slices.Values([]int{1, 2, 3}))
+			s2 := hashset.Collect(slices.Values([]int{2, 3, 4}))
+
+			testutil.NoDeadlock(t, func() {
+				var wg sync.WaitGroup
+				pairs := [][2]*hashset.HashSet[int]{{s1, s2}, {s2, s1}, {s1, s1}}
+				for _, pair := range pairs {
+					wg.Add(2)
+					go func() {
+						defer wg.Done()
+						for range 5000 {
+							op(pair[0], pair[1])
+						}
+					}()
+					go func() {
+						defer wg.Done()
+						for i := range 5000 {
+							pair[0].Add(i % 8)
+							pair[0].Remove(i % 8)
+						}
+					}()
+				}
+				wg.Wait()
+			})
+		})
+	}
+}
+
+func TestSetOperationsAliased(t *testing.T) {
+	s := hashset.Collect(slices.Values([]int{1, 2, 3}))
+
+	assert.True(t, hashset.Equal(s, s))
+	assert.True(t, hashset.Equal(hashset.Union(s, s), s))
+	assert.True(t, hashset.Equal(hashset.Intersection(s, s), s))
+	assert.True(t, hashset.Difference(s, s).Empty())
+	assert.True(t, hashset.SymmetricDifference(s, s).Empty())
+	assert.True(t, s.IsSubset(s))
+	assert.False(t, s.IsDisjoint(s))
+
+	// Callbacks may access the set they are called on.
+	assert.True(t, hashset.Equal(s.Filter(s.Contains), s))
+	in, _ := s.Partition(func(v int) bool { return s.Contains(v + 1) })
+	assert.ElementsMatch(t, []int{1, 2}, slices.Collect(in.All()))
+	s.AddAll(s.All())
+	assert.Equal(t, 3, s.Len())
+}
@@ -3,9 +3,13 @@
 package priorityqueue
 
 import (
+	"iter"
+	"slices"
 	"sync"
+	"unsafe"
 
 	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
 )
 
 // PriorityQueue is a generic priority queue with a configurable comparision
@@ -16,6 +20,8 @@ type PriorityQueue[T any] struct {
 	comparator comparator.Comparator[T]
 }
 
+var _ container.PriorityQueue[int] = (*PriorityQueue[int])(nil)
+
 // New creates a new [PriorityQueue] with the specified comparator.
 func New[T any](comparator comparator.Comparator[T]) *PriorityQueue[T] {
 	return &PriorityQueue[T]{
@@ -40,6 +46,15 @@ func (pq *PriorityQueue[T]) Empty() bool {
 	return len(pq.data) == 0
 }
 
+// Clear removes all elements from the priority queue.
+func (pq *PriorityQueue[T]) Clear() {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	clear(pq.data)
+	pq.data = pq.data[:0]
+}
+
 // Push adds an element to the priority queue.
 func (pq *PriorityQueue[T]) Push(v T) {
 	pq.mu.Lock()
@@ -81,6 +96,133 @@ func (pq *PriorityQueue[T]) Pop() (T, bool) {
 	return top, true
 }
 
+// FromSlice returns a new [PriorityQueue] ordered by comparator holding
+// a copy of vals.
+//
+// It builds the heap in O(n) time, which is faster than pushing the
+// elements one by one.
+func FromSlice[T any](vals []T, comparator comparator.Comparator[T]) *PriorityQueue[T] {
+	pq := New(comparator)
+	pq.data = append(pq.data, vals...)
+	pq.heapify()
+	return pq
+}
+
+// Collect collects values from an iterator into a new [PriorityQueue]
+// ordered by comparator. Like [FromSlice], it builds the heap in O(n) time.
+func Collect[T any](seq iter.Seq[T], comparator comparator.Comparator[T]) *PriorityQueue[T] {
+	pq := New(comparator)
+	pq.data = slices.AppendSeq(pq.data, seq)
+	pq.heapify()
+	return pq
+}
+
+// PushAll adds all elements from an iterator to the priority queue.
+//
+// The elements are collected before the lock is taken, so seq may
+// access the priority queue.
+func (pq *PriorityQueue[T]) PushAll(seq iter.Seq[T]) {
+	vals := slices.Collect(seq)
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	pq.pushAll(vals)
+}
+
+// Merge adds all elements of other to the priority queue, ordering them
+// by the comparator of pq. other is not modified.
+func (pq *PriorityQueue[T]) Merge(other *PriorityQueue[T]) {
+	if pq == other {
+		pq.mu.Lock()
+		defer pq.mu.Unlock()
+
+		pq.pushAll(pq.data)
+		return
+	}
+
+	// Take the locks in order of addresses so that concurrent merges in
+	// opposite directions cannot deadlock.
+	if uintptr(unsafe.Pointer(pq)) < uintptr(unsafe.Pointer(other)) {
+		pq.mu.Lock()
+		other.mu.RLock()
+	} else {
+		other.mu.RLock()
+		pq.mu.Lock()
+	}
+	defer pq.mu.Unlock()
+	defer other.mu.RUnlock()
+
+	pq.pushAll(other.data)
+}
+
+// pushAll adds vals to the heap, rebuilding it from scratch when that is
+// cheaper than sifting up each value.
+func (pq *PriorityQueue[T]) pushAll(vals []T) {
+	n := len(pq.data)
+	pq.data = append(pq.data, vals...)
+	if len(vals) > n/2 {
+		pq.heapify()
+		return
+	}
+	for i := n; i < len(pq.data); i++ {
+		pq.siftUp(i)
+	}
+}
+
+// Clone returns a copy of the priority queue.
+func (pq *PriorityQueue[T]) Clone() *PriorityQueue[T] {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+
+	return &PriorityQueue[T]{
+		data:       slices.Clone(pq.data),
+		comparator: pq.comparator,
+	}
+}
+
+// All returns an iterator over the elements in the priority queue in
+// unspecified order.
+//
+// The priority queue is read-locked during the iteration, so the loop
+// body must not modify it. Use [PriorityQueue.Clone] to iterate over a
+// copy instead.
+func (pq *PriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		pq.mu.RLock()
+		defer pq.mu.RUnlock()
+
+		for _, v := range pq.data {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops and yields the elements of the
+// priority queue from the highest priority to the lowest, until it is
+// empty or the iteration stops.
+//
+// Each element is popped separately, so other goroutines may push or pop
+// elements concurrently and the loop body may access the priority queue.
+func (pq *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := pq.Pop()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (pq *PriorityQueue[T]) heapify() {
+	for i := len(pq.data)/2 - 1; i >= 0; i-- {
+		pq.siftDown(i)
+	}
+}
+
 func (pq *PriorityQueue[T]) siftUp(index int) {
 	for {
 		parent := (index - 1) / 2
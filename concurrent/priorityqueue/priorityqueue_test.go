@@ -3,11 +3,15 @@ package priorityqueue_test
 import (
 	"cmp"
 	"math/rand/v2"
+	"runtime"
 	"slices"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/linhns/gocontainers/concurrent/priorityqueue"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/internal/lincheck"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -117,3 +121,190 @@ func TestPriorityQueueConcurrent(t *testing.T) {
 	close(start)
 	wg.Wait()
 }
+
+func TestPriorityQueueCollect(t *testing.T) {
+	nums := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	pq := priorityqueue.Collect(slices.Values(nums), cmp.Compare[int])
+	assert.Equal(t, len(nums), pq.Len())
+
+	slices.Sort(nums)
+	slices.Reverse(nums)
+	for _, want := range nums {
+		val, ok := pq.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, want, val)
+	}
+}
+
+func TestPriorityQueueClear(t *testing.T) {
+	pq := priorityqueue.Collect(slices.Values([]int{3, 1, 2}), cmp.Compare[int])
+	pq.Clear()
+	assert.True(t, pq.Empty())
+
+	pq.Push(1)
+	val, ok := pq.Top()
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+}
+
+func TestPriorityQueueFromSlice(t *testing.T) {
+	nums := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	pq := priorityqueue.FromSlice(nums, cmp.Compare[int])
+	assert.Equal(t, []int{3, 1, 4, 1, 5, 9, 2, 6}, nums)
+	assert.Equal(t, []int{9, 6, 5, 4, 3, 2, 1, 1}, slices.Collect(pq.Drain()))
+	assert.True(t, pq.Empty())
+
+	pq = priorityqueue.FromSlice([]int(nil), cmp.Compare[int])
+	assert.True(t, pq.Empty())
+}
+
+func TestPriorityQueuePushAllMerge(t *testing.T) {
+	pq := priorityqueue.FromSlice([]int{5, 1}, cmp.Compare[int])
+	pq.PushAll(slices.Values([]int{3}))
+	pq.PushAll(slices.Values([]int{8, 2, 7, 0}))
+
+	other := priorityqueue.FromSlice([]int{4, 6}, cmp.Compare[int])
+	pq.Merge(other)
+	assert.Equal(t, 2, other.Len())
+
+	assert.Equal(t, []int{8, 7, 6, 5, 4, 3, 2, 1, 0}, slices.Collect(pq.Drain()))
+
+	other.Merge(other)
+	other.PushAll(other.All())
+	assert.Equal(t, []int{6, 6, 6, 6, 4, 4, 4, 4}, slices.Collect(other.Drain()))
+}
+
+func TestPriorityQueueCloneAllDrain(t *testing.T) {
+	pq := priorityqueue.FromSlice([]int{3, 1, 2}, cmp.Compare[int])
+	c := pq.Clone()
+	c.Push(10)
+	assert.Equal(t, 3, pq.Len())
+	assert.ElementsMatch(t, []int{3, 1, 2}, slices.Collect(pq.All()))
+	assert.ElementsMatch(t, []int{10, 3, 1, 2}, slices.Collect(c.All()))
+
+	for v := range c.Drain() {
+		assert.Equal(t, 10, v)
+		break
+	}
+	assert.Equal(t, 3, c.Len())
+	for range c.All() {
+		break
+	}
+}
+
+func TestPriorityQueueMergeConcurrent(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(max(4, runtime.NumCPU())))
+
+	pq1 := priorityqueue.FromSlice([]int{1, 2, 3}, cmp.Compare[int])
+	pq2 := priorityqueue.FromSlice([]int{4, 5, 6}, cmp.Compare[int])
+
+	// Merging in opposite directions at the same time must not deadlock.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for _, pair := range [][2]*priorityqueue.PriorityQueue[int]{{pq1, pq2}, {pq2, pq1}} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 200 {
+					pair[0].Merge(pair[1])
+					pair[0].Clear()
+					pair[0].PushAll(slices.Values([]int{1, 2, 3}))
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("deadlock")
+	}
+}
+
+func TestPriorityQueueDrainConcurrent(t *testing.T) {
+	pq := priorityqueue.New(cmp.Compare[int])
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 250 {
+				pq.Push(i*250 + j)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The loop body may use the queue while draining it.
+	var got []int
+	for v := range pq.Drain() {
+		got = append(got, v)
+		if v == 500 {
+			pq.Push(-1)
+		}
+	}
+	assert.Len(t, got, 1001)
+	assert.True(t, slices.IsSortedFunc(got, func(a, b int) int { return cmp.Compare(b, a) }))
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestPriorityQueue(t, priorityqueue.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestPriorityQueueModel(t, priorityqueue.New[int])
+}
+
+func FuzzPriorityQueue(f *testing.F) {
+	containertest.FuzzPriorityQueue(f, priorityqueue.New[int])
+}
+
+type pqInput struct {
+	push  bool
+	value int
+}
+
+type pqOutput struct {
+	value int
+	ok    bool
+}
+
+// pqModel keeps the queued elements sorted in increasing order.
+var pqModel = lincheck.Model[[]int, pqInput, pqOutput]{
+	Init: func() []int { return nil },
+	Step: func(state []int, in pqInput, out pqOutput) (bool, []int) {
+		if in.push {
+			i, _ := slices.BinarySearch(state, in.value)
+			return true, slices.Insert(slices.Clone(state), i, in.value)
+		}
+		if len(state) == 0 {
+			return !out.ok, state
+		}
+		top := state[len(state)-1]
+		return out.ok && out.value == top, state[:len(state)-1]
+	},
+}
+
+func TestPriorityQueueLinearizable(t *testing.T) {
+	for range 50 {
+		pq := priorityqueue.New(cmp.Compare[int])
+		var rec lincheck.Recorder[pqInput, pqOutput]
+		lincheck.Run(4, 8, func(client, i int) {
+			if (client+i)%3 != 2 {
+				in := pqInput{push: true, value: rand.IntN(100)}
+				rec.Record(client, in, func() pqOutput {
+					pq.Push(in.value)
+					return pqOutput{}
+				})
+				return
+			}
+			rec.Record(client, pqInput{}, func() pqOutput {
+				v, ok := pq.Pop()
+				return pqOutput{v, ok}
+			})
+		})
+		assert.True(t, lincheck.Check(pqModel, rec.History()))
+	}
+}
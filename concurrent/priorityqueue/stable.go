@@ -0,0 +1,101 @@
+package priorityqueue
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
+	seqpriorityqueue "github.com/linhns/gocontainers/priorityqueue"
+)
+
+// StablePriorityQueue is a priority queue in which elements with equal
+// priorities are popped in the order they were pushed. It is safe for
+// concurrent use.
+//
+// The order of pushes by different goroutines is the order in which they
+// acquire the lock.
+type StablePriorityQueue[T any] struct {
+	mu sync.RWMutex
+	pq *seqpriorityqueue.StablePriorityQueue[T]
+}
+
+var _ container.PriorityQueue[int] = (*StablePriorityQueue[int])(nil)
+
+// NewStable creates a new [StablePriorityQueue] with the specified
+// comparator.
+func NewStable[T any](comparator comparator.Comparator[T]) *StablePriorityQueue[T] {
+	return &StablePriorityQueue[T]{
+		pq: seqpriorityqueue.NewStable(comparator),
+	}
+}
+
+// Len returns the number of elements in the priority queue.
+func (pq *StablePriorityQueue[T]) Len() int {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+
+	return pq.pq.Len()
+}
+
+// Empty reports whether the priority queue is empty.
+func (pq *StablePriorityQueue[T]) Empty() bool {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+
+	return pq.pq.Empty()
+}
+
+// Clear removes all elements from the priority queue.
+func (pq *StablePriorityQueue[T]) Clear() {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	pq.pq.Clear()
+}
+
+// Push adds an element to the priority queue.
+func (pq *StablePriorityQueue[T]) Push(v T) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	pq.pq.Push(v)
+}
+
+// Top returns the element with the maximal priority in the queue that
+// was pushed first. If the queue is empty, it returns the zero value of
+// the element type and false.
+func (pq *StablePriorityQueue[T]) Top() (T, bool) {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+
+	return pq.pq.Top()
+}
+
+// Pop returns the element with the maximal priority in the queue that
+// was pushed first, and removes it from the queue. If the queue is empty,
+// it returns the zero value of the element type and false.
+func (pq *StablePriorityQueue[T]) Pop() (T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	return pq.pq.Pop()
+}
+
+// All returns an iterator over the elements in the priority queue in
+// unspecified order.
+//
+// The priority queue is read-locked during the iteration, so the loop
+// body must not modify it.
+func (pq *StablePriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		pq.mu.RLock()
+		defer pq.mu.RUnlock()
+
+		for v := range pq.pq.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package priorityqueue_test
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/linhns/gocontainers/concurrent/priorityqueue"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+type job struct {
+	priority int
+	producer int
+	id       int
+}
+
+func TestStablePriorityQueueConcurrent(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(max(4, runtime.NumCPU())))
+
+	pq := priorityqueue.NewStable(func(a, b job) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
+	var wg sync.WaitGroup
+	for p := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range 500 {
+				pq.Push(job{id % 2, p, id})
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 2000, pq.Len())
+	assert.Len(t, slices.Collect(pq.All()), 2000)
+
+	// Each producer's jobs of equal priority must pop in push order.
+	last := make(map[[2]int]int)
+	for !pq.Empty() {
+		j, _ := pq.Pop()
+		key := [2]int{j.priority, j.producer}
+		if prev, ok := last[key]; ok {
+			assert.Greater(t, j.id, prev)
+		}
+		last[key] = j.id
+	}
+
+	pq.Push(job{})
+	top, ok := pq.Top()
+	assert.True(t, ok)
+	assert.Equal(t, job{}, top)
+	pq.Clear()
+	assert.True(t, pq.Empty())
+}
+
+func TestStableConformance(t *testing.T) {
+	containertest.TestPriorityQueue(t, priorityqueue.NewStable[int])
+}
+
+func TestStableModel(t *testing.T) {
+	containertest.TestPriorityQueueModel(t, priorityqueue.NewStable[int])
+}
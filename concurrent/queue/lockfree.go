@@ -0,0 +1,147 @@
+package queue
+
+import (
+	"sync/atomic"
+
+	"github.com/linhns/gocontainers/container"
+)
+
+// A LockFreeQueue is a FIFO data structure safe for concurrent use
+// by multiple producers and consumers without locking.
+//
+// It implements the non-blocking queue of Michael and Scott.
+// Because popped nodes are reclaimed by the garbage collector only once
+// no goroutine can reference them, the queue is not subject to the ABA
+// problem. The zero value is an empty queue ready to use.
+type LockFreeQueue[T any] struct {
+	head atomic.Pointer[node[T]]
+	tail atomic.Pointer[node[T]]
+	len  atomic.Int64
+}
+
+var _ container.Queue[int] = (*LockFreeQueue[int])(nil)
+
+type node[T any] struct {
+	value T
+	next  atomic.Pointer[node[T]]
+}
+
+// NewLockFree creates and initializes a new [LockFreeQueue].
+func NewLockFree[T any]() *LockFreeQueue[T] {
+	q := &LockFreeQueue[T]{}
+	sentinel := &node[T]{}
+	q.head.Store(sentinel)
+	q.tail.Store(sentinel)
+	return q
+}
+
+// Empty reports whether the queue is empty.
+func (q *LockFreeQueue[T]) Empty() bool {
+	q.init()
+	return q.head.Load().next.Load() == nil
+}
+
+// Len returns the number of elements in the queue.
+//
+// While other goroutines are modifying the queue, the result is only
+// an approximation.
+func (q *LockFreeQueue[T]) Len() int {
+	return int(max(q.len.Load(), 0))
+}
+
+// Clear removes all elements from the queue.
+//
+// Clear is not atomic: elements pushed concurrently may or may not
+// be removed.
+func (q *LockFreeQueue[T]) Clear() {
+	for {
+		if _, ok := q.Pop(); !ok {
+			return
+		}
+	}
+}
+
+// Push adds an element to the back of the queue.
+func (q *LockFreeQueue[T]) Push(val T) {
+	q.init()
+	n := &node[T]{value: val}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// The tail is lagging behind, help advance it.
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			q.len.Add(1)
+			return
+		}
+	}
+}
+
+// Front returns the element at the front of the queue.
+//
+// It returns the zero value of T and false if the queue is empty.
+// Otherwise, it returns the element and true.
+func (q *LockFreeQueue[T]) Front() (T, bool) {
+	q.init()
+	for {
+		head := q.head.Load()
+		next := head.next.Load()
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+		val := next.value
+		if head == q.head.Load() {
+			return val, true
+		}
+	}
+}
+
+// Pop removes and returns the element at the front of the queue.
+//
+// It returns the zero value of T and false if the queue is empty.
+// Otherwise, it returns the element and true.
+func (q *LockFreeQueue[T]) Pop() (T, bool) {
+	q.init()
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+		if head == tail {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if q.head.CompareAndSwap(head, next) {
+			q.len.Add(-1)
+			return next.value, true
+		}
+	}
+}
+
+// init installs the sentinel node of a zero-value queue. The head is set
+// before the tail, so a queue with a tail is fully initialized.
+func (q *LockFreeQueue[T]) init() {
+	if q.tail.Load() != nil {
+		return
+	}
+	sentinel := &node[T]{}
+	if !q.head.CompareAndSwap(nil, sentinel) {
+		// Another goroutine installed its sentinel first, help it finish.
+		sentinel = q.head.Load()
+	}
+	q.tail.CompareAndSwap(nil, sentinel)
+}
@@ -0,0 +1,173 @@
+package queue_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/concurrent/queue"
+	"github.com/linhns/gocontainers/container"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/internal/lincheck"
+)
+
+func TestLockFreeQueueConformance(t *testing.T) {
+	containertest.TestQueue(t, queue.NewLockFree[int])
+}
+
+func TestLockFreeQueueModel(t *testing.T) {
+	containertest.TestQueueModel(t, queue.NewLockFree[int])
+}
+
+func TestLockFreeQueueZeroValue(t *testing.T) {
+	var q queue.LockFreeQueue[int]
+	assert.True(t, q.Empty())
+	_, ok := q.Front()
+	assert.False(t, ok)
+
+	q.Push(1)
+	val, ok := q.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+
+	// Goroutines racing to initialize the queue must agree on a sentinel.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(max(runtime.GOMAXPROCS(0), 4)))
+	for range 100 {
+		var q queue.LockFreeQueue[int]
+		var wg sync.WaitGroup
+		for i := range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				q.Push(i)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 4, q.Len())
+		var got []int
+		for v, ok := q.Pop(); ok; v, ok = q.Pop() {
+			got = append(got, v)
+		}
+		assert.ElementsMatch(t, []int{0, 1, 2, 3}, got)
+	}
+}
+
+func TestLockFreeQueueLinearizable(t *testing.T) {
+	for range 50 {
+		q := queue.NewLockFree[int]()
+		var rec lincheck.Recorder[queueInput, queueOutput]
+		lincheck.Run(4, 8, func(client, i int) {
+			if (client+i)%2 == 0 {
+				in := queueInput{push: true, value: client*100 + i}
+				rec.Record(client, in, func() queueOutput {
+					q.Push(in.value)
+					return queueOutput{}
+				})
+				return
+			}
+			rec.Record(client, queueInput{}, func() queueOutput {
+				v, ok := q.Pop()
+				return queueOutput{v, ok}
+			})
+		})
+		assert.True(t, lincheck.Check(queueModel, rec.History()))
+	}
+}
+
+func TestLockFreeQueueStress(t *testing.T) {
+	const producers, consumers, perProducer = 8, 8, 1000
+
+	q := queue.NewLockFree[int]()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		received = make([]int, 0, producers*perProducer)
+		done     = make(chan struct{})
+	)
+
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				q.Push(p*perProducer + i)
+			}
+		}()
+	}
+
+	var consumersWg sync.WaitGroup
+	for range consumers {
+		consumersWg.Add(1)
+		go func() {
+			defer consumersWg.Done()
+			// last tracks the last value popped from each producer,
+			// which must be increasing since each producer pushes in order.
+			last := make(map[int]int)
+			for {
+				v, ok := q.Pop()
+				if !ok {
+					select {
+					case <-done:
+						if q.Empty() {
+							return
+						}
+					default:
+					}
+					runtime.Gosched()
+					continue
+				}
+				p := v / perProducer
+				if prev, seen := last[p]; seen {
+					assert.Less(t, prev, v)
+				}
+				last[p] = v
+
+				mu.Lock()
+				received = append(received, v)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(done)
+	consumersWg.Wait()
+
+	assert.Len(t, received, producers*perProducer)
+	assert.True(t, q.Empty())
+	assert.Equal(t, 0, q.Len())
+}
+
+func benchmarkQueue(b *testing.B, newQueue func() container.Queue[int]) {
+	for _, procs := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("procs=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+
+			q := newQueue()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if i%2 == 0 {
+						q.Push(i)
+					} else {
+						q.Pop()
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkQueuePushPop(b *testing.B) {
+	b.Run("mutex", func(b *testing.B) {
+		benchmarkQueue(b, func() container.Queue[int] { return queue.New[int]() })
+	})
+	b.Run("lockfree", func(b *testing.B) {
+		benchmarkQueue(b, func() container.Queue[int] { return queue.NewLockFree[int]() })
+	})
+}
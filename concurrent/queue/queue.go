@@ -2,7 +2,12 @@
 // safe for concurrent use.
 package queue
 
-import "sync"
+import (
+	"iter"
+	"sync"
+
+	"github.com/linhns/gocontainers/container"
+)
 
 // A Queue is a FIFO data structure.
 type Queue[T any] struct {
@@ -10,6 +15,8 @@ type Queue[T any] struct {
 	data []T
 }
 
+var _ container.Queue[int] = (*Queue[int])(nil)
+
 // New creates and initializes a new [Queue].
 func New[T any]() *Queue[T] {
 	return &Queue[T]{}
@@ -31,6 +38,15 @@ func (q *Queue[T]) Len() int {
 	return len(q.data)
 }
 
+// Clear removes all elements from the queue.
+func (q *Queue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	clear(q.data)
+	q.data = q.data[:0]
+}
+
 // Push adds an element to the back of the queue.
 func (q *Queue[T]) Push(val T) {
 	q.mu.Lock()
@@ -71,3 +87,13 @@ func (q *Queue[T]) Pop() (T, bool) {
 	q.data = q.data[1:]
 	return val, true
 }
+
+// Collect collects values from an iterator into a new queue.
+// The first value yielded is at the front of the queue.
+func Collect[T any](seq iter.Seq[T]) *Queue[T] {
+	q := New[T]()
+	for v := range seq {
+		q.data = append(q.data, v)
+	}
+	return q
+}
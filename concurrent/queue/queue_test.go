@@ -1,10 +1,13 @@
 package queue_test
 
 import (
+	"slices"
 	"sync"
 	"testing"
 
 	"github.com/linhns/gocontainers/concurrent/queue"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/internal/lincheck"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -60,3 +63,83 @@ func TestQueueConcurrent(t *testing.T) {
 	close(start)
 	wg.Wait()
 }
+
+func TestQueueCollect(t *testing.T) {
+	q := queue.Collect(slices.Values([]int{1, 2, 3}))
+	assert.Equal(t, 3, q.Len())
+
+	for want := 1; want <= 3; want++ {
+		val, ok := q.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, want, val)
+	}
+}
+
+func TestQueueClear(t *testing.T) {
+	q := queue.Collect(slices.Values([]int{1, 2, 3}))
+	q.Pop()
+	q.Clear()
+	assert.True(t, q.Empty())
+
+	q.Push(4)
+	val, ok := q.Front()
+	assert.True(t, ok)
+	assert.Equal(t, 4, val)
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestQueue(t, queue.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestQueueModel(t, queue.New[int])
+}
+
+func FuzzQueue(f *testing.F) {
+	containertest.FuzzQueue(f, queue.New[int])
+}
+
+type queueInput struct {
+	push  bool
+	value int
+}
+
+type queueOutput struct {
+	value int
+	ok    bool
+}
+
+var queueModel = lincheck.Model[[]int, queueInput, queueOutput]{
+	Init: func() []int { return nil },
+	Step: func(state []int, in queueInput, out queueOutput) (bool, []int) {
+		if in.push {
+			return true, append(slices.Clip(state), in.value)
+		}
+		if len(state) == 0 {
+			return !out.ok, state
+		}
+		return out.ok && out.value == state[0], state[1:]
+	},
+}
+
+func TestQueueLinearizable(t *testing.T) {
+	for range 50 {
+		q := queue.New[int]()
+		var rec lincheck.Recorder[queueInput, queueOutput]
+		lincheck.Run(4, 8, func(client, i int) {
+			if (client+i)%2 == 0 {
+				in := queueInput{push: true, value: client*100 + i}
+				rec.Record(client, in, func() queueOutput {
+					q.Push(in.value)
+					return queueOutput{}
+				})
+				return
+			}
+			rec.Record(client, queueInput{}, func() queueOutput {
+				v, ok := q.Pop()
+				return queueOutput{v, ok}
+			})
+		})
+		assert.True(t, lincheck.Check(queueModel, rec.History()))
+	}
+}
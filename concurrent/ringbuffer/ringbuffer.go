@@ -0,0 +1,121 @@
+// Package ringbuffer implements a circular buffer with a fixed capacity
+// safe for concurrent use.
+package ringbuffer
+
+import (
+	"sync/atomic"
+)
+
+// cacheLineSize is a common size of a CPU cache line, used to keep the
+// producer and consumer indices from sharing one.
+const cacheLineSize = 64
+
+// An SPSC is a FIFO data structure with a fixed capacity safe for
+// concurrent use by a single producer and a single consumer without
+// locking.
+//
+// Only one goroutine at a time may call Push, the producer, and only one
+// goroutine at a time may call Pop, Front and Clear, the consumer. Other
+// methods may be called from any goroutine.
+type SPSC[T any] struct {
+	data []T
+	// head and tail count the elements popped and pushed so far, so the
+	// buffer is full when they differ by the capacity. Each is written by
+	// a single goroutine.
+	head atomic.Uint64
+	_    [cacheLineSize - 8]byte
+	tail atomic.Uint64
+	_    [cacheLineSize - 8]byte
+}
+
+// NewSPSC creates a new [SPSC] that holds at most capacity elements.
+//
+// If capacity is not positive, NewSPSC panics.
+func NewSPSC[T any](capacity int) *SPSC[T] {
+	if capacity <= 0 {
+		panic("ringbuffer.NewSPSC: non-positive capacity")
+	}
+	return &SPSC[T]{data: make([]T, capacity)}
+}
+
+// Len returns the number of elements in the buffer.
+//
+// While other goroutines are modifying the buffer, the result is only
+// an approximation.
+func (b *SPSC[T]) Len() int {
+	head := b.head.Load()
+	return min(int(b.tail.Load()-head), len(b.data))
+}
+
+// Cap returns the maximum number of elements in the buffer.
+func (b *SPSC[T]) Cap() int {
+	return len(b.data)
+}
+
+// Empty reports whether the buffer is empty.
+func (b *SPSC[T]) Empty() bool {
+	return b.Len() == 0
+}
+
+// Full reports whether the buffer holds Cap elements.
+func (b *SPSC[T]) Full() bool {
+	return b.Len() == len(b.data)
+}
+
+// Clear removes all elements from the buffer. It must be called by the
+// consumer.
+//
+// Clear is not atomic: elements pushed concurrently may or may not
+// be removed.
+func (b *SPSC[T]) Clear() {
+	for {
+		if _, ok := b.Pop(); !ok {
+			return
+		}
+	}
+}
+
+// Push adds an element to the back of the buffer and reports whether
+// there was room for it. If the buffer is full, v is discarded. It must
+// be called by the producer.
+func (b *SPSC[T]) Push(v T) bool {
+	tail := b.tail.Load()
+	if tail-b.head.Load() == uint64(len(b.data)) {
+		return false
+	}
+	b.data[tail%uint64(len(b.data))] = v
+	b.tail.Store(tail + 1)
+	return true
+}
+
+// Front returns the oldest element in the buffer. It must be called by
+// the consumer.
+//
+// It returns the zero value of T and false if the buffer is empty.
+// Otherwise, it returns the element and true.
+func (b *SPSC[T]) Front() (T, bool) {
+	head := b.head.Load()
+	if head == b.tail.Load() {
+		var zero T
+		return zero, false
+	}
+	return b.data[head%uint64(len(b.data))], true
+}
+
+// Pop removes and returns the oldest element in the buffer. It must be
+// called by the consumer.
+//
+// It returns the zero value of T and false if the buffer is empty.
+// Otherwise, it returns the element and true.
+func (b *SPSC[T]) Pop() (T, bool) {
+	var zero T
+	head := b.head.Load()
+	if head == b.tail.Load() {
+		return zero, false
+	}
+	i := head % uint64(len(b.data))
+	v := b.data[i]
+	b.data[i] = zero
+	b.head.Store(head + 1)
+	return v, true
+}
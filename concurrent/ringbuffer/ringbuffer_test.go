@@ -0,0 +1,118 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/concurrent/ringbuffer"
+)
+
+func TestSPSC(t *testing.T) {
+	b := ringbuffer.NewSPSC[int](3)
+	assert.True(t, b.Empty())
+	assert.Equal(t, 3, b.Cap())
+
+	_, ok := b.Pop()
+	assert.False(t, ok)
+	_, ok = b.Front()
+	assert.False(t, ok)
+
+	for i := 1; i <= 3; i++ {
+		assert.True(t, b.Push(i))
+	}
+	assert.True(t, b.Full())
+	assert.False(t, b.Push(4))
+	assert.Equal(t, 3, b.Len())
+
+	// Wrap around the end of the buffer several times.
+	for i := 1; i <= 10; i++ {
+		front, ok := b.Front()
+		assert.True(t, ok)
+		v, ok := b.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+		assert.Equal(t, front, v)
+		assert.True(t, b.Push(i+3))
+	}
+
+	b.Clear()
+	assert.True(t, b.Empty())
+	assert.Equal(t, 0, b.Len())
+	assert.True(t, b.Push(42))
+	v, _ := b.Front()
+	assert.Equal(t, 42, v)
+}
+
+func TestNewSPSCPanics(t *testing.T) {
+	assert.PanicsWithValue(t, "ringbuffer.NewSPSC: non-positive capacity", func() {
+		ringbuffer.NewSPSC[int](-1)
+	})
+}
+
+func TestSPSCStress(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(max(runtime.GOMAXPROCS(0), 4)))
+
+	const n = 10000
+	b := ringbuffer.NewSPSC[int](16)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; {
+			if b.Push(i) {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	for want := 0; want < n; {
+		assert.LessOrEqual(t, b.Len(), b.Cap())
+		v, ok := b.Pop()
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		if v != want {
+			t.Fatalf("popped %d, want %d", v, want)
+		}
+		want++
+	}
+	wg.Wait()
+	assert.True(t, b.Empty())
+}
+
+func BenchmarkSPSC(b *testing.B) {
+	for _, capacity := range []int{16, 1024} {
+		b.Run(fmt.Sprintf("capacity=%d", capacity), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(max(runtime.GOMAXPROCS(0), 2)))
+
+			r := ringbuffer.NewSPSC[int](capacity)
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for i := 0; i < b.N; {
+					if _, ok := r.Pop(); ok {
+						i++
+					} else {
+						runtime.Gosched()
+					}
+				}
+			}()
+			for i := 0; i < b.N; {
+				if r.Push(i) {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+			}
+			<-done
+		})
+	}
+}
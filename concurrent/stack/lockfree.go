@@ -0,0 +1,95 @@
+package stack
+
+import (
+	"sync/atomic"
+
+	"github.com/linhns/gocontainers/container"
+)
+
+// A LockFreeStack is a Last-In-First-Out (LIFO) data structure safe for
+// concurrent use without locking.
+//
+// It implements Treiber's stack. Nodes are immutable once pushed and are
+// never reused, and the garbage collector keeps a node alive for as long
+// as any goroutine references it, so the stack is not subject to the ABA
+// problem. The zero value is an empty stack ready to use.
+type LockFreeStack[T any] struct {
+	top atomic.Pointer[node[T]]
+}
+
+var _ container.Stack[int] = (*LockFreeStack[int])(nil)
+
+type node[T any] struct {
+	value T
+	next  *node[T]
+	// size is the number of elements in the stack whose top is this node.
+	size int
+}
+
+// NewLockFree creates and initializes a new [LockFreeStack].
+func NewLockFree[T any]() *LockFreeStack[T] {
+	return &LockFreeStack[T]{}
+}
+
+// Empty reports whether the stack is empty.
+func (s *LockFreeStack[T]) Empty() bool {
+	return s.top.Load() == nil
+}
+
+// Len returns the number of elements in the stack.
+func (s *LockFreeStack[T]) Len() int {
+	top := s.top.Load()
+	if top == nil {
+		return 0
+	}
+	return top.size
+}
+
+// Clear removes all elements from the stack.
+func (s *LockFreeStack[T]) Clear() {
+	s.top.Store(nil)
+}
+
+// Push adds an element to the top of the stack.
+func (s *LockFreeStack[T]) Push(val T) {
+	n := &node[T]{value: val}
+	for {
+		top := s.top.Load()
+		n.next = top
+		n.size = 1
+		if top != nil {
+			n.size += top.size
+		}
+		if s.top.CompareAndSwap(top, n) {
+			return
+		}
+	}
+}
+
+// Top returns the element at the top of the stack.
+//
+// If the stack is empty, it returns the zero value of T and false.
+func (s *LockFreeStack[T]) Top() (T, bool) {
+	top := s.top.Load()
+	if top == nil {
+		var zero T
+		return zero, false
+	}
+	return top.value, true
+}
+
+// Pop removes and returns the element at the top of the stack.
+//
+// If the stack is empty, it returns the zero value of T and false.
+func (s *LockFreeStack[T]) Pop() (T, bool) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			var zero T
+			return zero, false
+		}
+		if s.top.CompareAndSwap(top, top.next) {
+			return top.value, true
+		}
+	}
+}
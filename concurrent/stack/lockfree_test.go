@@ -0,0 +1,124 @@
+package stack_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/concurrent/stack"
+	"github.com/linhns/gocontainers/container"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/internal/lincheck"
+)
+
+func TestLockFreeStackConformance(t *testing.T) {
+	containertest.TestStack(t, stack.NewLockFree[int])
+}
+
+func TestLockFreeStackModel(t *testing.T) {
+	containertest.TestStackModel(t, stack.NewLockFree[int])
+}
+
+func TestLockFreeStackZeroValue(t *testing.T) {
+	var s stack.LockFreeStack[int]
+	assert.True(t, s.Empty())
+
+	s.Push(1)
+	val, ok := s.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+}
+
+func TestLockFreeStackLinearizable(t *testing.T) {
+	for range 50 {
+		s := stack.NewLockFree[int]()
+		var rec lincheck.Recorder[stackInput, stackOutput]
+		lincheck.Run(4, 8, func(client, i int) {
+			if (client+i)%2 == 0 {
+				in := stackInput{push: true, value: client*100 + i}
+				rec.Record(client, in, func() stackOutput {
+					s.Push(in.value)
+					return stackOutput{}
+				})
+				return
+			}
+			rec.Record(client, stackInput{}, func() stackOutput {
+				v, ok := s.Pop()
+				return stackOutput{v, ok}
+			})
+		})
+		assert.True(t, lincheck.Check(stackModel, rec.History()))
+	}
+}
+
+func TestLockFreeStackStress(t *testing.T) {
+	const goroutines, perGoroutine = 16, 2000
+
+	s := stack.NewLockFree[int]()
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		popped = make(map[int]bool)
+	)
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perGoroutine {
+				s.Push(g*perGoroutine + i)
+				if i%2 == 1 {
+					v, ok := s.Pop()
+					assert.True(t, ok)
+
+					mu.Lock()
+					assert.False(t, popped[v], "value %d popped twice", v)
+					popped[v] = true
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, goroutines*perGoroutine/2, s.Len())
+	for !s.Empty() {
+		v, _ := s.Pop()
+		assert.False(t, popped[v], "value %d popped twice", v)
+		popped[v] = true
+	}
+	assert.Len(t, popped, goroutines*perGoroutine)
+}
+
+func benchmarkStack(b *testing.B, newStack func() container.Stack[int]) {
+	for _, procs := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("procs=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+
+			s := newStack()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if i%2 == 0 {
+						s.Push(i)
+					} else {
+						s.Pop()
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkStackPushPop(b *testing.B) {
+	b.Run("mutex", func(b *testing.B) {
+		benchmarkStack(b, func() container.Stack[int] { return stack.New[int]() })
+	})
+	b.Run("lockfree", func(b *testing.B) {
+		benchmarkStack(b, func() container.Stack[int] { return stack.NewLockFree[int]() })
+	})
+}
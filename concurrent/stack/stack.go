@@ -3,7 +3,10 @@
 package stack
 
 import (
+	"iter"
 	"sync"
+
+	"github.com/linhns/gocontainers/container"
 )
 
 // A Stack is a Last-In-First-Out (LIFO) data structure.
@@ -12,6 +15,8 @@ type Stack[T any] struct {
 	data []T
 }
 
+var _ container.Stack[int] = (*Stack[int])(nil)
+
 // New creates and initializes a new [Stack].
 func New[T any]() *Stack[T] {
 	return &Stack[T]{}
@@ -33,6 +38,15 @@ func (s *Stack[T]) Len() int {
 	return len(s.data)
 }
 
+// Clear removes all elements from the stack.
+func (s *Stack[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.data)
+	s.data = s.data[:0]
+}
+
 // Push adds an element to the top of the stack.
 func (s *Stack[T]) Push(val T) {
 	s.mu.Lock()
@@ -71,3 +85,13 @@ func (s *Stack[T]) Pop() (T, bool) {
 	s.data = s.data[:index]
 	return val, true
 }
+
+// Collect collects values from an iterator into a new stack.
+// The last value yielded is at the top of the stack.
+func Collect[T any](seq iter.Seq[T]) *Stack[T] {
+	s := New[T]()
+	for v := range seq {
+		s.data = append(s.data, v)
+	}
+	return s
+}
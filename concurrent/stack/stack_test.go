@@ -1,10 +1,13 @@
 package stack_test
 
 import (
+	"slices"
 	"sync"
 	"testing"
 
 	"github.com/linhns/gocontainers/concurrent/stack"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/internal/lincheck"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,3 +59,83 @@ func TestStackConcurrent(t *testing.T) {
 	close(start)
 	wg.Wait()
 }
+
+func TestStackCollect(t *testing.T) {
+	s := stack.Collect(slices.Values([]int{1, 2, 3}))
+	assert.Equal(t, 3, s.Len())
+
+	for want := 3; want >= 1; want-- {
+		val, ok := s.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, want, val)
+	}
+}
+
+func TestStackClear(t *testing.T) {
+	s := stack.Collect(slices.Values([]int{1, 2, 3}))
+	s.Clear()
+	assert.True(t, s.Empty())
+
+	s.Push(4)
+	val, ok := s.Top()
+	assert.True(t, ok)
+	assert.Equal(t, 4, val)
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestStack(t, stack.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestStackModel(t, stack.New[int])
+}
+
+func FuzzStack(f *testing.F) {
+	containertest.FuzzStack(f, stack.New[int])
+}
+
+type stackInput struct {
+	push  bool
+	value int
+}
+
+type stackOutput struct {
+	value int
+	ok    bool
+}
+
+var stackModel = lincheck.Model[[]int, stackInput, stackOutput]{
+	Init: func() []int { return nil },
+	Step: func(state []int, in stackInput, out stackOutput) (bool, []int) {
+		if in.push {
+			return true, append(slices.Clip(state), in.value)
+		}
+		if len(state) == 0 {
+			return !out.ok, state
+		}
+		top := state[len(state)-1]
+		return out.ok && out.value == top, state[:len(state)-1]
+	},
+}
+
+func TestStackLinearizable(t *testing.T) {
+	for range 50 {
+		s := stack.New[int]()
+		var rec lincheck.Recorder[stackInput, stackOutput]
+		lincheck.Run(4, 8, func(client, i int) {
+			if (client+i)%2 == 0 {
+				in := stackInput{push: true, value: client*100 + i}
+				rec.Record(client, in, func() stackOutput {
+					s.Push(in.value)
+					return stackOutput{}
+				})
+				return
+			}
+			rec.Record(client, stackInput{}, func() stackOutput {
+				v, ok := s.Pop()
+				return stackOutput{v, ok}
+			})
+		})
+		assert.True(t, lincheck.Check(stackModel, rec.History()))
+	}
+}
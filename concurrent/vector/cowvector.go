@@ -0,0 +1,303 @@
+package vector
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/linhns/gocontainers/container"
+	seqvector "github.com/linhns/gocontainers/vector"
+)
+
+// CowVector is a copy-on-write vector for read-mostly workloads. It is
+// safe for concurrent use.
+//
+// Reads are wait-free: they load the current elements through an atomic
+// pointer to an immutable slice and never block. Every modification
+// copies the elements, applies the change to the copy and publishes it,
+// so writes are O(n) and serialized with each other.
+//
+// Iterators range over the elements as of the start of the iteration,
+// so the loop body may freely modify the vector.
+//
+// The zero value is an empty vector ready to use.
+type CowVector[T any] struct {
+	mu   sync.Mutex
+	data atomic.Pointer[[]T]
+}
+
+var _ container.Sequence[int] = (*CowVector[int])(nil)
+
+// NewCow creates and initializes a new [CowVector].
+func NewCow[T any]() *CowVector[T] {
+	return &CowVector[T]{}
+}
+
+// NewCowWithCapacity creates and initializes a new [CowVector]
+// with a specified capacity.
+func NewCowWithCapacity[T any](capacity int) *CowVector[T] {
+	v := &CowVector[T]{}
+	v.store(make([]T, 0, capacity))
+	return v
+}
+
+// CowOf constructs a new [CowVector] with initial values.
+func CowOf[T any](vals ...T) *CowVector[T] {
+	v := &CowVector[T]{}
+	v.store(slices.Clone(vals))
+	return v
+}
+
+// CollectCow collects values from an iterator and returns a new
+// [CowVector].
+func CollectCow[T any](seq iter.Seq[T]) *CowVector[T] {
+	v := &CowVector[T]{}
+	v.store(slices.Collect(seq))
+	return v
+}
+
+func (v *CowVector[T]) load() []T {
+	if p := v.data.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+func (v *CowVector[T]) store(data []T) {
+	v.data.Store(&data)
+}
+
+// update replaces the elements with the result of f applied to them,
+// while holding the writer lock. f must not modify its argument, which
+// may be shared with concurrent readers.
+func (v *CowVector[T]) update(f func(data []T) []T) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.store(f(v.load()))
+}
+
+// PushBack adds an element to the end of the vector
+func (v *CowVector[T]) PushBack(val T) {
+	v.update(func(data []T) []T {
+		return append(slices.Clip(data), val)
+	})
+}
+
+// PopBack removes and returns the last element from the vector.
+// If the vector is empty, it returns a zero value and false.
+func (v *CowVector[T]) PopBack() (T, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	data := v.load()
+	if len(data) == 0 {
+		var zero T
+		return zero, false
+	}
+	idx := len(data) - 1
+	val := data[idx]
+	v.store(slices.Clone(data[:idx]))
+	return val, true
+}
+
+// Len returns the number of elements in the vector.
+func (v *CowVector[T]) Len() int {
+	return len(v.load())
+}
+
+// Cap returns the number of elements that the current copy of the
+// vector can hold. Since every modification copies the elements,
+// the capacity is only informative.
+func (v *CowVector[T]) Cap() int {
+	return cap(v.load())
+}
+
+// Empty reports whether the vector is empty.
+func (v *CowVector[T]) Empty() bool {
+	return len(v.load()) == 0
+}
+
+// Get returns the zero-indexed ith element of v, if any.
+func (v *CowVector[T]) Get(i int) (value T, ok bool) {
+	data := v.load()
+	if i >= 0 && i < len(data) {
+		return data[i], true
+	}
+	return
+}
+
+// Set sets the zero-indexed ith element of v to value.
+//
+// Set panics if n is negative or greater than or equal to the length of v.
+func (v *CowVector[T]) Set(i int, value T) {
+	v.update(func(data []T) []T {
+		if i < 0 || i >= len(data) {
+			panic("vector.Set: index out of range")
+		}
+		data = slices.Clone(data)
+		data[i] = value
+		return data
+	})
+}
+
+// Front returns the first element of v, if any.
+func (v *CowVector[T]) Front() (value T, ok bool) {
+	data := v.load()
+	if len(data) > 0 {
+		value = data[0]
+		ok = true
+	}
+	return
+}
+
+// Back returns the last element of v, if any.
+func (v *CowVector[T]) Back() (value T, ok bool) {
+	data := v.load()
+	if len(data) > 0 {
+		value = data[len(data)-1]
+		ok = true
+	}
+	return
+}
+
+// Clear removes all elements from the vector
+func (v *CowVector[T]) Clear() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.store(nil)
+}
+
+// Grow increases the capacity of the vector, if necessary,
+// to guarantee space for another n elements.
+//
+// Since every modification copies the elements, Grow does not avoid
+// allocations and is provided for compatibility with [Vector].
+//
+// If n is negative, Grow panics.
+func (v *CowVector[T]) Grow(n int) {
+	if n < 0 {
+		panic("vector.Grow: negative count")
+	}
+	v.update(func(data []T) []T {
+		return slices.Grow(slices.Clip(data), n)
+	})
+}
+
+// Clip reduces the capacity of the vector as much as possible.
+func (v *CowVector[T]) Clip() {
+	v.update(slices.Clip)
+}
+
+// Resize changes the size of the vector to n. If n is less than the current
+// size, the vector is truncated to the first n elements. If n is greater than
+// the current size, the vector is grown to n elements, with the additional
+// elements initialized to zero.
+//
+// If n is negative, Resize panics.
+func (v *CowVector[T]) Resize(n int) {
+	if n < 0 {
+		panic("vector.Resize: negative count")
+	}
+	v.update(func(data []T) []T {
+		if n < len(data) {
+			return slices.Clone(data[:n])
+		}
+		return slices.Concat(data, make([]T, n-len(data)))
+	})
+}
+
+// Insert inserts the values vals... into v at index i.
+//
+// Insert panics if i is out of range.
+// This function is O(v.Len() + len(vals))
+func (v *CowVector[T]) Insert(i int, vals ...T) {
+	v.update(func(data []T) []T {
+		if i < 0 || i > len(data) {
+			panic("vector.Insert: index out of range")
+		}
+		return slices.Concat(data[:i], vals, data[i:])
+	})
+}
+
+// Remove removes the element at index i from v.
+//
+// Remove panics if i is out of range.
+func (v *CowVector[T]) Remove(i int) {
+	v.RemoveRange(i, i+1)
+}
+
+// RemoveRange removes the elements with indices in range [i, j) from v.
+// Removing an empty range (i >= j) is a no-op.
+//
+// RemoveRange panics if either i or j is out of range.
+func (v *CowVector[T]) RemoveRange(i, j int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	data := v.load()
+	if i < 0 || j < 0 || i > len(data) || j > len(data) {
+		panic("vector.RemoveRange: index out of range")
+	}
+	if i >= j {
+		return
+	}
+	v.store(slices.Concat(data[:i], data[j:]))
+}
+
+// CowEqual reports whether two vectors are equal. It compares the elements
+// of each vector as of the start of the call, without locking.
+func CowEqual[T comparable](v1, v2 *CowVector[T]) bool {
+	return slices.Equal(v1.load(), v2.load())
+}
+
+// Values returns an iterator that yields the vector elements in order.
+func (v *CowVector[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, val := range v.load() {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over index-value pairs in the vector.
+func (v *CowVector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, val := range v.load() {
+			if !yield(i, val) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs in the vector,
+// traversing it backward with decreasing indices.
+func (v *CowVector[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		data := v.load()
+		for i := len(data) - 1; i >= 0; i-- {
+			if !yield(i, data[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the vector in O(1), sharing the current
+// elements until either vector is modified.
+func (v *CowVector[T]) Clone() *CowVector[T] {
+	c := &CowVector[T]{}
+	c.store(v.load())
+	return c
+}
+
+// Snapshot returns a copy of the elements of the vector as a vector
+// that is not safe for concurrent use.
+func (v *CowVector[T]) Snapshot() *seqvector.Vector[T] {
+	return seqvector.Of(slices.Clone(v.load())...)
+}
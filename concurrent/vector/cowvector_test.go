@@ -0,0 +1,160 @@
+package vector_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/linhns/gocontainers/concurrent/vector"
+	"github.com/linhns/gocontainers/container"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCowVectorConformance(t *testing.T) {
+	containertest.TestSequence(t, vector.NewCow[int])
+}
+
+func TestCowVectorModel(t *testing.T) {
+	containertest.TestSequenceModel(t, vector.NewCow[int])
+}
+
+func TestCowVectorLinearizable(t *testing.T) {
+	testLinearizable(t, func() container.Sequence[int] { return vector.NewCow[int]() })
+}
+
+func TestCowVectorConstructors(t *testing.T) {
+	var zero vector.CowVector[int]
+	assert.True(t, zero.Empty())
+	zero.PushBack(1)
+	assert.Equal(t, 1, zero.Len())
+
+	v := vector.NewCowWithCapacity[int](8)
+	assert.True(t, v.Empty())
+	assert.Equal(t, 8, v.Cap())
+
+	vals := []int{1, 2, 3}
+	v = vector.CowOf(vals...)
+	vals[0] = 10
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(v.Values()))
+
+	v = vector.CollectCow(slices.Values(vals))
+	assert.Equal(t, vals, slices.Collect(v.Values()))
+}
+
+func TestCowVectorCapacity(t *testing.T) {
+	v := vector.CowOf(1, 2)
+	v.Grow(10)
+	assert.GreaterOrEqual(t, v.Cap(), 12)
+	v.Clip()
+	assert.Equal(t, 2, v.Cap())
+
+	v.Resize(4)
+	assert.Equal(t, []int{1, 2, 0, 0}, slices.Collect(v.Values()))
+	v.Resize(1)
+	assert.Equal(t, []int{1}, slices.Collect(v.Values()))
+
+	assert.Panics(t, func() { v.Grow(-1) })
+	assert.Panics(t, func() { v.Resize(-1) })
+}
+
+func TestCowVectorEqual(t *testing.T) {
+	v1 := vector.NewCow[int]()
+	var v2 vector.CowVector[int]
+	assert.True(t, vector.CowEqual(v1, &v2))
+	assert.True(t, vector.CowEqual(v1, v1))
+
+	v1.PushBack(1)
+	assert.False(t, vector.CowEqual(v1, &v2))
+
+	v2.PushBack(1)
+	assert.True(t, vector.CowEqual(v1, &v2))
+
+	v1.PushBack(2)
+	v2.PushBack(3)
+	assert.False(t, vector.CowEqual(v1, &v2))
+}
+
+func TestCowVectorIterateWhileModifying(t *testing.T) {
+	v := vector.CowOf(1, 2, 3)
+
+	for i, x := range v.All() {
+		v.Set(i, x*10)
+		v.PushBack(x)
+	}
+	assert.Equal(t, []int{10, 20, 30, 1, 2, 3}, slices.Collect(v.Values()))
+
+	var backward []int
+	for _, x := range v.Backward() {
+		backward = append(backward, x)
+		v.Clear()
+	}
+	assert.Equal(t, []int{3, 2, 1, 30, 20, 10}, backward)
+	assert.True(t, v.Empty())
+}
+
+func TestCowVectorCloneSnapshot(t *testing.T) {
+	v := vector.CowOf(1, 2, 3)
+	c := v.Clone()
+	snap := v.Snapshot()
+
+	v.Set(0, 10)
+	c.PushBack(4)
+	snap.Set(1, 20)
+
+	assert.Equal(t, []int{10, 2, 3}, slices.Collect(v.Values()))
+	assert.Equal(t, []int{1, 2, 3, 4}, slices.Collect(c.Values()))
+	assert.Equal(t, []int{1, 20, 3}, slices.Collect(snap.Values()))
+}
+
+func TestCowVectorConcurrentReaders(t *testing.T) {
+	v := vector.CowOf(make([]int, 16)...)
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for range 1000 {
+				// Every published version holds equal elements.
+				first, _ := v.Front()
+				for x := range v.Values() {
+					assert.Equal(t, first, x)
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-start
+		for i := range 200 {
+			v.Resize(0)
+			v.Insert(0, slices.Repeat([]int{i}, 16)...)
+		}
+	}()
+
+	close(start)
+	wg.Wait()
+}
+
+func BenchmarkGet(b *testing.B) {
+	vals := make([]int, 1024)
+	impls := map[string]container.Sequence[int]{
+		"rwmutex": vector.Of(vals...),
+		"cow":     vector.CowOf(vals...),
+	}
+	for name, v := range impls {
+		b.Run(name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					v.Get(i % len(vals))
+					i++
+				}
+			})
+		})
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"iter"
 	"slices"
 	"sync"
+	"unsafe"
+
+	"github.com/linhns/gocontainers/container"
+	seqvector "github.com/linhns/gocontainers/vector"
 )
 
 // Vector represent a growable collection of elements
@@ -14,6 +18,8 @@ type Vector[T any] struct {
 	mu   sync.RWMutex
 }
 
+var _ container.Sequence[int] = (*Vector[int])(nil)
+
 // New creates and initializes a new [Vector]
 func New[T any]() *Vector[T] {
 	return &Vector[T]{
@@ -244,16 +250,42 @@ func (v *Vector[T]) RemoveRange(i, j int) {
 }
 
 // Equal reports whether two vectors are equal.
+//
+// It is safe to call Equal with the same vector as both arguments, and
+// concurrently with swapped arguments.
 func Equal[T comparable](v1, v2 *Vector[T]) bool {
-	v1.mu.RLock()
-	defer v1.mu.RUnlock()
-	v2.mu.RLock()
-	defer v2.mu.RUnlock()
+	if v1 == v2 {
+		return true
+	}
+	unlock := rlockPair(v1, v2)
+	defer unlock()
 
 	return slices.Equal(v1.data, v2.data)
 }
 
+// rlockPair read-locks two distinct vectors in order of their addresses
+// and returns a function that unlocks them.
+//
+// Acquiring the locks in a global order prevents the deadlock in which
+// two goroutines each hold one read lock and wait for the other while
+// writers are queued on both.
+func rlockPair[T any](v1, v2 *Vector[T]) (unlock func()) {
+	if uintptr(unsafe.Pointer(v2)) < uintptr(unsafe.Pointer(v1)) {
+		v1, v2 = v2, v1
+	}
+	v1.mu.RLock()
+	v2.mu.RLock()
+	return func() {
+		v2.mu.RUnlock()
+		v1.mu.RUnlock()
+	}
+}
+
 // Values returns an iterator that yields the vector elements in order.
+//
+// The vector is read-locked during the iteration, so the loop body must
+// not modify it. To modify the vector while iterating, iterate over
+// a [Vector.Snapshot] instead.
 func (v *Vector[T]) Values() iter.Seq[T] {
 	return func(yield func(T) bool) {
 		v.mu.RLock()
@@ -268,6 +300,10 @@ func (v *Vector[T]) Values() iter.Seq[T] {
 }
 
 // All returns an iterator over index-value pairs in the vector.
+//
+// The vector is read-locked during the iteration, so the loop body must
+// not modify it. To modify the vector while iterating, iterate over
+// a [Vector.Snapshot] instead.
 func (v *Vector[T]) All() iter.Seq2[int, T] {
 	return func(yield func(int, T) bool) {
 		v.mu.RLock()
@@ -283,6 +319,10 @@ func (v *Vector[T]) All() iter.Seq2[int, T] {
 
 // Backward returns an iterator over index-value pairs in the vector,
 // traversing it backward with decreasing indices.
+//
+// The vector is read-locked during the iteration, so the loop body must
+// not modify it. To modify the vector while iterating, iterate over
+// a [Vector.Snapshot] instead.
 func (v *Vector[T]) Backward() iter.Seq2[int, T] {
 	return func(yield func(int, T) bool) {
 		v.mu.RLock()
@@ -296,6 +336,32 @@ func (v *Vector[T]) Backward() iter.Seq2[int, T] {
 	}
 }
 
+// Clone returns a copy of the vector.
+func (v *Vector[T]) Clone() *Vector[T] {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return &Vector[T]{
+		data: slices.Clone(v.data),
+	}
+}
+
+// Snapshot returns a copy of the elements of the vector as a vector
+// that is not safe for concurrent use.
+//
+// Iterating over a snapshot does not hold any lock, so the loop body
+// may freely modify the original vector:
+//
+//	for i, x := range v.Snapshot().All() {
+//		v.Set(i, x*2)
+//	}
+func (v *Vector[T]) Snapshot() *seqvector.Vector[T] {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return seqvector.Of(slices.Clone(v.data)...)
+}
+
 // Collect collects values from an iterator and returns a new vector.
 func Collect[T any](seq iter.Seq[T]) *Vector[T] {
 	data := slices.Collect(seq)
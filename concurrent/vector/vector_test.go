@@ -1,10 +1,16 @@
 package vector_test
 
 import (
+	"math/rand/v2"
+	"slices"
 	"sync"
 	"testing"
 
 	"github.com/linhns/gocontainers/concurrent/vector"
+	"github.com/linhns/gocontainers/container"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/internal/lincheck"
+	"github.com/linhns/gocontainers/internal/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -456,3 +462,168 @@ func TestVectorIteratorConcurrent(t *testing.T) {
 	}()
 	close(start)
 }
+
+func TestConformance(t *testing.T) {
+	containertest.TestSequence(t, vector.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestSequenceModel(t, vector.New[int])
+}
+
+func FuzzVector(f *testing.F) {
+	containertest.FuzzSequence(f, vector.New[int])
+}
+
+type vectorOp int
+
+const (
+	opPushBack vectorOp = iota
+	opPopBack
+	opGet
+	opLen
+)
+
+type vectorInput struct {
+	op    vectorOp
+	value int
+}
+
+type vectorOutput struct {
+	value int
+	ok    bool
+}
+
+var vectorModel = lincheck.Model[[]int, vectorInput, vectorOutput]{
+	Init: func() []int { return nil },
+	Step: func(state []int, in vectorInput, out vectorOutput) (bool, []int) {
+		switch in.op {
+		case opPushBack:
+			return true, append(slices.Clip(state), in.value)
+		case opPopBack:
+			if len(state) == 0 {
+				return !out.ok, state
+			}
+			return out.ok && out.value == state[len(state)-1], state[:len(state)-1]
+		case opGet:
+			if in.value >= len(state) {
+				return !out.ok, state
+			}
+			return out.ok && out.value == state[in.value], state
+		default:
+			return out.value == len(state), state
+		}
+	},
+}
+
+func TestVectorLinearizable(t *testing.T) {
+	testLinearizable(t, func() container.Sequence[int] { return vector.New[int]() })
+}
+
+func testLinearizable(t *testing.T, newVector func() container.Sequence[int]) {
+	for range 50 {
+		v := newVector()
+		var rec lincheck.Recorder[vectorInput, vectorOutput]
+		lincheck.Run(4, 8, func(client, i int) {
+			in := vectorInput{op: vectorOp(rand.IntN(4))}
+			switch in.op {
+			case opPushBack:
+				in.value = client*100 + i
+				rec.Record(client, in, func() vectorOutput {
+					v.PushBack(in.value)
+					return vectorOutput{}
+				})
+			case opPopBack:
+				rec.Record(client, in, func() vectorOutput {
+					val, ok := v.PopBack()
+					return vectorOutput{val, ok}
+				})
+			case opGet:
+				in.value = rand.IntN(4)
+				rec.Record(client, in, func() vectorOutput {
+					val, ok := v.Get(in.value)
+					return vectorOutput{val, ok}
+				})
+			case opLen:
+				rec.Record(client, in, func() vectorOutput {
+					return vectorOutput{value: v.Len()}
+				})
+			}
+		})
+		assert.True(t, lincheck.Check(vectorModel, rec.History()))
+	}
+}
+
+func TestEqualAliasedWithWriter(t *testing.T) {
+	v := vector.Of(1, 2, 3)
+
+	testutil.NoDeadlock(t, func() {
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 10000 {
+				assert.True(t, vector.Equal(v, v))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := range 10000 {
+				v.Set(0, i)
+			}
+		}()
+		wg.Wait()
+	})
+}
+
+func TestEqualSwappedWithWriters(t *testing.T) {
+	v1 := vector.Of(1, 2, 3)
+	v2 := vector.Of(1, 2, 3)
+
+	testutil.NoDeadlock(t, func() {
+		var wg sync.WaitGroup
+		for _, pair := range [][2]*vector.Vector[int]{{v1, v2}, {v2, v1}} {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for range 10000 {
+					vector.Equal(pair[0], pair[1])
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for i := range 10000 {
+					pair[0].Set(0, i)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestClone(t *testing.T) {
+	v := vector.Of(1, 2, 3)
+	c := v.Clone()
+	assert.True(t, vector.Equal(v, c))
+
+	c.PushBack(4)
+	v.Set(0, 10)
+	assert.Equal(t, []int{10, 2, 3}, slices.Collect(v.Values()))
+	assert.Equal(t, []int{1, 2, 3, 4}, slices.Collect(c.Values()))
+}
+
+func TestSnapshotMutateWhileIterating(t *testing.T) {
+	v := vector.Of(1, 2, 3)
+
+	testutil.NoDeadlock(t, func() {
+		for i, x := range v.Snapshot().All() {
+			v.Set(i, x*2)
+			v.PushBack(x)
+		}
+	})
+	assert.Equal(t, []int{2, 4, 6, 1, 2, 3}, slices.Collect(v.Values()))
+
+	snap := v.Snapshot()
+	v.Clear()
+	assert.Equal(t, 6, snap.Len())
+}
@@ -0,0 +1,77 @@
+// Package container defines interfaces shared by the containers
+// in this module, so that implementations can be swapped freely.
+package container
+
+import "iter"
+
+// Container is the set of methods common to every container.
+type Container interface {
+	// Len returns the number of elements in the container.
+	Len() int
+	// Empty reports whether the container is empty.
+	Empty() bool
+	// Clear removes all elements from the container.
+	Clear()
+}
+
+// Sequence is an indexable, growable collection of elements.
+type Sequence[T any] interface {
+	Container
+	Get(i int) (T, bool)
+	Set(i int, value T)
+	Front() (T, bool)
+	Back() (T, bool)
+	PushBack(val T)
+	PopBack() (T, bool)
+	Insert(i int, vals ...T)
+	Remove(i int)
+	RemoveRange(i, j int)
+	Values() iter.Seq[T]
+	All() iter.Seq2[int, T]
+}
+
+// Set is a collection of unique elements.
+type Set[K comparable] interface {
+	Container
+	Add(key K)
+	Remove(key K)
+	Contains(key K) bool
+	All() iter.Seq[K]
+}
+
+// Map is a collection of key-value pairs with unique keys.
+type Map[K comparable, V any] interface {
+	Container
+	Insert(key K, value V)
+	Get(key K) (V, bool)
+	Contains(key K) bool
+	Remove(key K)
+	Keys() iter.Seq[K]
+	Values() iter.Seq[V]
+	All() iter.Seq2[K, V]
+}
+
+// Queue is a First-In-First-Out (FIFO) collection.
+type Queue[T any] interface {
+	Container
+	Push(val T)
+	Pop() (T, bool)
+	Front() (T, bool)
+}
+
+// Stack is a Last-In-First-Out (LIFO) collection.
+type Stack[T any] interface {
+	Container
+	Push(val T)
+	Pop() (T, bool)
+	Top() (T, bool)
+}
+
+// PriorityQueue is a collection whose Top and Pop return
+// the element with the maximal priority.
+type PriorityQueue[T any] interface {
+	Container
+	Push(val T)
+	Pop() (T, bool)
+	Top() (T, bool)
+}
@@ -0,0 +1,68 @@
+package container_test
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cpriorityqueue "github.com/linhns/gocontainers/concurrent/priorityqueue"
+	cqueue "github.com/linhns/gocontainers/concurrent/queue"
+	cstack "github.com/linhns/gocontainers/concurrent/stack"
+	"github.com/linhns/gocontainers/container"
+	"github.com/linhns/gocontainers/priorityqueue"
+	"github.com/linhns/gocontainers/queue"
+	"github.com/linhns/gocontainers/stack"
+)
+
+// drainQueue pushes vals into q and pops everything back out.
+func drainQueue(q container.Queue[int], vals ...int) []int {
+	for _, v := range vals {
+		q.Push(v)
+	}
+	var out []int
+	for !q.Empty() {
+		v, _ := q.Pop()
+		out = append(out, v)
+	}
+	return out
+}
+
+func drainStack(s container.Stack[int], vals ...int) []int {
+	for _, v := range vals {
+		s.Push(v)
+	}
+	var out []int
+	for !s.Empty() {
+		v, _ := s.Pop()
+		out = append(out, v)
+	}
+	return out
+}
+
+func drainPriorityQueue(pq container.PriorityQueue[int], vals ...int) []int {
+	for _, v := range vals {
+		pq.Push(v)
+	}
+	var out []int
+	for !pq.Empty() {
+		v, _ := pq.Pop()
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestSwapImplementations(t *testing.T) {
+	t.Parallel()
+
+	vals := []int{1, 3, 2}
+
+	assert.Equal(t, []int{1, 3, 2}, drainQueue(queue.New[int](), vals...))
+	assert.Equal(t, []int{1, 3, 2}, drainQueue(cqueue.New[int](), vals...))
+
+	assert.Equal(t, []int{2, 3, 1}, drainStack(stack.New[int](), vals...))
+	assert.Equal(t, []int{2, 3, 1}, drainStack(cstack.New[int](), vals...))
+
+	assert.Equal(t, []int{3, 2, 1}, drainPriorityQueue(priorityqueue.New(cmp.Compare[int]), vals...))
+	assert.Equal(t, []int{3, 2, 1}, drainPriorityQueue(cpriorityqueue.New(cmp.Compare[int]), vals...))
+}
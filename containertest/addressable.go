@@ -0,0 +1,157 @@
+package containertest
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
+)
+
+// Handle is a reference to an element of an addressable priority queue.
+type Handle[T any] interface {
+	comparable
+	Value() T
+}
+
+// AddressablePriorityQueue is a priority queue that returns a handle to
+// each element it stores, through which the element can be updated or
+// removed, and that can meld another queue of type P into itself.
+type AddressablePriorityQueue[H Handle[int], P any] interface {
+	container.PriorityQueue[int]
+	Insert(v int) H
+	TopElement() H
+	Update(e H, v int)
+	Remove(e H)
+	Meld(other P)
+	All() iter.Seq[int]
+}
+
+// TestAddressablePriorityQueue runs the conformance suite for
+// [AddressablePriorityQueue]. newPQ must return a new, empty priority
+// queue ordered by the given comparator on every call. The handle type
+// cannot be inferred and must be given explicitly:
+//
+//	containertest.TestAddressablePriorityQueue[*pairingheap.Element[int]](t, pairingheap.New[int])
+func TestAddressablePriorityQueue[H Handle[int], P AddressablePriorityQueue[H, P]](t *testing.T, newPQ func(comparator.Comparator[int]) P) {
+	t.Helper()
+
+	fill := func(vals ...int) P {
+		pq := newPQ(cmp.Compare[int])
+		for _, v := range vals {
+			pq.Push(v)
+		}
+		return pq
+	}
+
+	t.Run("Basics", func(t *testing.T) {
+		pq := newPQ(cmp.Compare[int])
+		var none H
+		assert.Equal(t, none, pq.TopElement())
+
+		for _, v := range []int{5, 3, 8, 1} {
+			pq.Push(v)
+		}
+		assert.Equal(t, 4, pq.Len())
+		assert.Equal(t, 8, pq.TopElement().Value())
+		assert.ElementsMatch(t, []int{5, 3, 8, 1}, slices.Collect(pq.All()))
+		assert.Equal(t, []int{8, 5, 3, 1}, drain(pq))
+	})
+
+	t.Run("UpdateRemove", func(t *testing.T) {
+		pq := newPQ(cmp.Compare[int])
+		elems := make(map[int]H)
+		for _, v := range []int{10, 20, 30, 40, 50} {
+			elems[v] = pq.Insert(v)
+		}
+		pq.Pop()
+
+		pq.Update(elems[10], 100)
+		v, _ := pq.Top()
+		assert.Equal(t, 100, v)
+		assert.Equal(t, 100, elems[10].Value())
+
+		pq.Update(elems[10], 5)
+		v, _ = pq.Top()
+		assert.Equal(t, 40, v)
+
+		pq.Remove(elems[40])
+		pq.Remove(elems[20])
+		assert.Equal(t, 2, pq.Len())
+		assert.ElementsMatch(t, []int{5, 30}, slices.Collect(pq.All()))
+
+		assert.Panics(t, func() { pq.Update(elems[40], 1) })
+		assert.Panics(t, func() { pq.Remove(elems[50]) })
+	})
+
+	t.Run("UpdateRandom", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(1, 2))
+		pq := newPQ(cmp.Compare[int])
+		var elems []H
+		model := make(map[H]int)
+		for range 20000 {
+			switch op := r.IntN(10); {
+			case op < 4 || len(elems) == 0:
+				e := pq.Insert(r.IntN(1000))
+				elems = append(elems, e)
+				model[e] = e.Value()
+			case op < 7:
+				e := elems[r.IntN(len(elems))]
+				v := r.IntN(1000)
+				pq.Update(e, v)
+				model[e] = v
+			case op < 8:
+				i := r.IntN(len(elems))
+				pq.Remove(elems[i])
+				delete(model, elems[i])
+				elems = slices.Delete(elems, i, i+1)
+			default:
+				e := pq.TopElement()
+				assert.Equal(t, slices.Max(slices.Collect(maps.Values(model))), e.Value())
+				v, _ := pq.Pop()
+				assert.Equal(t, e.Value(), v)
+				delete(model, e)
+				elems = slices.DeleteFunc(elems, func(x H) bool { return x == e })
+			}
+			assert.Equal(t, len(model), pq.Len())
+		}
+	})
+
+	t.Run("Meld", func(t *testing.T) {
+		pq1 := fill(1, 5, 3)
+		pq2 := newPQ(cmp.Compare[int])
+		e := pq2.Insert(4)
+		pq2.Push(6)
+		pq1.Meld(pq2)
+		pq1.Meld(pq1)
+		pq1.Meld(newPQ(cmp.Compare[int]))
+		assertEmptyContainer(t, pq2)
+		assert.Equal(t, 5, pq1.Len())
+
+		// Handles of the melded queue are valid for the queue melded into.
+		pq1.Update(e, 10)
+		assert.Equal(t, []int{10, 6, 5, 3, 1}, drain(pq1))
+
+		pq2.Meld(fill(2))
+		v, _ := pq2.Top()
+		assert.Equal(t, 2, v)
+		pq2.Clear()
+		assertEmptyContainer(t, pq2)
+	})
+}
+
+// drain pops all elements of pq in order.
+func drain(pq container.PriorityQueue[int]) []int {
+	var out []int
+	for !pq.Empty() {
+		v, _ := pq.Pop()
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,440 @@
+// Package containertest implements behavioral conformance suites for
+// implementations of the interfaces in package container.
+//
+// Each suite takes a constructor and runs its checks as subtests of t,
+// so a new implementation is verified by a single call from its tests:
+//
+//	func TestConformance(t *testing.T) {
+//		containertest.TestSequence(t, vector.New[int])
+//	}
+package containertest
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
+)
+
+// TestSequence runs the conformance suite for [container.Sequence].
+// newSeq must return a new, empty sequence on every call.
+func TestSequence[S container.Sequence[int]](t *testing.T, newSeq func() S) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) {
+		s := newSeq()
+		assertEmptyContainer(t, s)
+
+		_, ok := s.Get(0)
+		assert.False(t, ok)
+		_, ok = s.Front()
+		assert.False(t, ok)
+		_, ok = s.Back()
+		assert.False(t, ok)
+		_, ok = s.PopBack()
+		assert.False(t, ok)
+		assert.Empty(t, slices.Collect(s.Values()))
+	})
+
+	t.Run("PushPopBack", func(t *testing.T) {
+		s := newSeq()
+		for i := range 10 {
+			s.PushBack(i)
+			assert.Equal(t, i+1, s.Len())
+			back, ok := s.Back()
+			assert.True(t, ok)
+			assert.Equal(t, i, back)
+		}
+		front, ok := s.Front()
+		assert.True(t, ok)
+		assert.Equal(t, 0, front)
+
+		for i := 9; i >= 0; i-- {
+			val, ok := s.PopBack()
+			assert.True(t, ok)
+			assert.Equal(t, i, val)
+		}
+		assertEmptyContainer(t, s)
+	})
+
+	t.Run("GetSet", func(t *testing.T) {
+		s := fillSequence(newSeq(), 0, 1, 2)
+
+		_, ok := s.Get(-1)
+		assert.False(t, ok)
+		_, ok = s.Get(3)
+		assert.False(t, ok)
+
+		s.Set(1, 10)
+		assert.Equal(t, []int{0, 10, 2}, slices.Collect(s.Values()))
+
+		assert.Panics(t, func() { s.Set(-1, 0) })
+		assert.Panics(t, func() { s.Set(3, 0) })
+	})
+
+	t.Run("Insert", func(t *testing.T) {
+		s := fillSequence(newSeq(), 1, 2, 3)
+
+		s.Insert(0, -1, 0)
+		s.Insert(s.Len(), 4)
+		s.Insert(3, 10, 11)
+		assert.Equal(t, []int{-1, 0, 1, 10, 11, 2, 3, 4}, slices.Collect(s.Values()))
+
+		assert.Panics(t, func() { s.Insert(-1, 0) })
+		assert.Panics(t, func() { s.Insert(s.Len()+1, 0) })
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		s := fillSequence(newSeq(), 0, 1, 2, 3, 4, 5)
+
+		s.Remove(0)
+		s.RemoveRange(1, 3)
+		s.RemoveRange(2, 2)
+		s.RemoveRange(3, 1)
+		assert.Equal(t, []int{1, 4, 5}, slices.Collect(s.Values()))
+
+		assert.Panics(t, func() { s.Remove(3) })
+		assert.Panics(t, func() { s.Remove(-1) })
+		assert.Panics(t, func() { s.RemoveRange(0, 4) })
+	})
+
+	t.Run("Iterators", func(t *testing.T) {
+		s := fillSequence(newSeq(), 5, 6, 7)
+
+		for i, v := range s.All() {
+			want, ok := s.Get(i)
+			assert.True(t, ok)
+			assert.Equal(t, want, v)
+		}
+		assert.Equal(t, []int{5, 6}, collectN(s.Values(), 2))
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		s := fillSequence(newSeq(), 1, 2, 3)
+		s.Clear()
+		assertEmptyContainer(t, s)
+
+		s.PushBack(4)
+		assert.Equal(t, []int{4}, slices.Collect(s.Values()))
+	})
+}
+
+// TestSet runs the conformance suite for [container.Set].
+// newSet must return a new, empty set on every call.
+func TestSet[S container.Set[int]](t *testing.T, newSet func() S) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) {
+		s := newSet()
+		assertEmptyContainer(t, s)
+		assert.False(t, s.Contains(0))
+		assert.Empty(t, slices.Collect(s.All()))
+	})
+
+	t.Run("AddRemove", func(t *testing.T) {
+		s := newSet()
+		s.Add(1)
+		s.Add(2)
+		s.Add(1)
+		assert.Equal(t, 2, s.Len())
+		assert.True(t, s.Contains(1))
+		assert.True(t, s.Contains(2))
+		assert.False(t, s.Contains(3))
+
+		s.Remove(3)
+		assert.Equal(t, 2, s.Len())
+		s.Remove(1)
+		assert.False(t, s.Contains(1))
+		assert.Equal(t, 1, s.Len())
+	})
+
+	t.Run("All", func(t *testing.T) {
+		s := newSet()
+		want := []int{3, 1, 4, 5, 9, 2, 6}
+		for _, v := range want {
+			s.Add(v)
+		}
+		assert.ElementsMatch(t, want, slices.Collect(s.All()))
+		assert.Len(t, collectN(s.All(), 3), 3)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		s := newSet()
+		s.Add(1)
+		s.Add(2)
+		s.Clear()
+		assertEmptyContainer(t, s)
+		assert.False(t, s.Contains(1))
+	})
+}
+
+// TestMap runs the conformance suite for [container.Map].
+// newMap must return a new, empty map on every call.
+func TestMap[M container.Map[int, string]](t *testing.T, newMap func() M) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) {
+		m := newMap()
+		assertEmptyContainer(t, m)
+		assert.False(t, m.Contains(0))
+		_, ok := m.Get(0)
+		assert.False(t, ok)
+		assert.Empty(t, slices.Collect(m.Keys()))
+	})
+
+	t.Run("InsertGetRemove", func(t *testing.T) {
+		m := newMap()
+		m.Insert(1, "one")
+		m.Insert(2, "two")
+		assert.Equal(t, 2, m.Len())
+
+		val, ok := m.Get(1)
+		assert.True(t, ok)
+		assert.Equal(t, "one", val)
+
+		m.Insert(1, "uno")
+		assert.Equal(t, 2, m.Len())
+		val, _ = m.Get(1)
+		assert.Equal(t, "uno", val)
+
+		m.Remove(3)
+		assert.Equal(t, 2, m.Len())
+		m.Remove(1)
+		assert.False(t, m.Contains(1))
+		assert.True(t, m.Contains(2))
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("Iterators", func(t *testing.T) {
+		m := newMap()
+		want := map[int]string{1: "one", 2: "two", 3: "three"}
+		for k, v := range want {
+			m.Insert(k, v)
+		}
+		assert.ElementsMatch(t, slices.Collect(maps.Keys(want)), slices.Collect(m.Keys()))
+		assert.ElementsMatch(t, slices.Collect(maps.Values(want)), slices.Collect(m.Values()))
+		assert.Equal(t, want, maps.Collect(m.All()))
+		assert.Len(t, collectN(m.Keys(), 2), 2)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		m := newMap()
+		m.Insert(1, "one")
+		m.Clear()
+		assertEmptyContainer(t, m)
+		assert.False(t, m.Contains(1))
+	})
+}
+
+// TestQueue runs the conformance suite for [container.Queue].
+// newQueue must return a new, empty queue on every call.
+func TestQueue[Q container.Queue[int]](t *testing.T, newQueue func() Q) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) {
+		q := newQueue()
+		assertEmptyContainer(t, q)
+		_, ok := q.Front()
+		assert.False(t, ok)
+		_, ok = q.Pop()
+		assert.False(t, ok)
+	})
+
+	t.Run("FIFO", func(t *testing.T) {
+		q := newQueue()
+		var want []int
+		for i := range 100 {
+			q.Push(i)
+			want = append(want, i)
+			if i%3 == 0 {
+				front, ok := q.Front()
+				assert.True(t, ok)
+				val, ok := q.Pop()
+				assert.True(t, ok)
+				assert.Equal(t, front, val)
+				assert.Equal(t, want[0], val)
+				want = want[1:]
+			}
+			assert.Equal(t, len(want), q.Len())
+		}
+		for _, w := range want {
+			val, ok := q.Pop()
+			assert.True(t, ok)
+			assert.Equal(t, w, val)
+		}
+		assertEmptyContainer(t, q)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		q := newQueue()
+		q.Push(1)
+		q.Push(2)
+		q.Clear()
+		assertEmptyContainer(t, q)
+
+		q.Push(3)
+		val, _ := q.Front()
+		assert.Equal(t, 3, val)
+	})
+}
+
+// TestStack runs the conformance suite for [container.Stack].
+// newStack must return a new, empty stack on every call.
+func TestStack[S container.Stack[int]](t *testing.T, newStack func() S) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) {
+		s := newStack()
+		assertEmptyContainer(t, s)
+		_, ok := s.Top()
+		assert.False(t, ok)
+		_, ok = s.Pop()
+		assert.False(t, ok)
+	})
+
+	t.Run("LIFO", func(t *testing.T) {
+		s := newStack()
+		var want []int
+		for i := range 100 {
+			s.Push(i)
+			want = append(want, i)
+			if i%3 == 0 {
+				top, ok := s.Top()
+				assert.True(t, ok)
+				val, ok := s.Pop()
+				assert.True(t, ok)
+				assert.Equal(t, top, val)
+				assert.Equal(t, want[len(want)-1], val)
+				want = want[:len(want)-1]
+			}
+			assert.Equal(t, len(want), s.Len())
+		}
+		for i := len(want) - 1; i >= 0; i-- {
+			val, ok := s.Pop()
+			assert.True(t, ok)
+			assert.Equal(t, want[i], val)
+		}
+		assertEmptyContainer(t, s)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		s := newStack()
+		s.Push(1)
+		s.Push(2)
+		s.Clear()
+		assertEmptyContainer(t, s)
+
+		s.Push(3)
+		val, _ := s.Top()
+		assert.Equal(t, 3, val)
+	})
+}
+
+// TestPriorityQueue runs the conformance suite for
+// [container.PriorityQueue]. newPQ must return a new, empty priority
+// queue ordered by the given comparator on every call.
+func TestPriorityQueue[P container.PriorityQueue[int]](t *testing.T, newPQ func(comparator.Comparator[int]) P) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) {
+		pq := newPQ(cmp.Compare[int])
+		assertEmptyContainer(t, pq)
+		_, ok := pq.Top()
+		assert.False(t, ok)
+		_, ok = pq.Pop()
+		assert.False(t, ok)
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		cmps := map[string]comparator.Comparator[int]{
+			"max": cmp.Compare[int],
+			"min": comparator.Reverse(cmp.Compare[int]),
+		}
+		for name, cmpr := range cmps {
+			t.Run(name, func(t *testing.T) {
+				pq := newPQ(cmpr)
+				nums := make([]int, 200)
+				for i := range nums {
+					nums[i] = rand.IntN(50)
+					pq.Push(nums[i])
+				}
+				assert.Equal(t, len(nums), pq.Len())
+
+				slices.SortFunc(nums, comparator.Reverse(cmpr))
+				for _, want := range nums {
+					top, ok := pq.Top()
+					assert.True(t, ok)
+					val, ok := pq.Pop()
+					assert.True(t, ok)
+					assert.Equal(t, top, val)
+					assert.Equal(t, want, val)
+				}
+				assertEmptyContainer(t, pq)
+			})
+		}
+	})
+
+	t.Run("Interleaved", func(t *testing.T) {
+		pq := newPQ(cmp.Compare[int])
+		var model []int
+		for i := range 300 {
+			if i%4 == 3 {
+				val, ok := pq.Pop()
+				assert.True(t, ok)
+				assert.Equal(t, slices.Max(model), val)
+				model = slices.Delete(model, slices.Index(model, val), slices.Index(model, val)+1)
+				continue
+			}
+			v := rand.IntN(1000)
+			pq.Push(v)
+			model = append(model, v)
+		}
+		assert.Equal(t, len(model), pq.Len())
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		pq := newPQ(cmp.Compare[int])
+		pq.Push(2)
+		pq.Push(1)
+		pq.Clear()
+		assertEmptyContainer(t, pq)
+
+		pq.Push(0)
+		val, _ := pq.Top()
+		assert.Equal(t, 0, val)
+	})
+}
+
+func assertEmptyContainer(t *testing.T, c container.Container) {
+	t.Helper()
+
+	assert.True(t, c.Empty())
+	assert.Equal(t, 0, c.Len())
+}
+
+func fillSequence[S container.Sequence[int]](s S, vals ...int) S {
+	for _, v := range vals {
+		s.PushBack(v)
+	}
+	return s
+}
+
+// collectN collects at most n values from seq, stopping the
+// iteration early.
+func collectN[T any](seq iter.Seq[T], n int) []T {
+	var out []T
+	for v := range seq {
+		out = append(out, v)
+		if len(out) == n {
+			break
+		}
+	}
+	return out
+}
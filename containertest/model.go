@@ -0,0 +1,511 @@
+package containertest
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+	"testing/quick"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
+)
+
+// quickConfig is the configuration used by the model-based tests.
+var quickConfig = &quick.Config{MaxCount: 300}
+
+// TestSequenceModel applies random operation sequences to sequences
+// returned by newSeq and compares their observable state against a plain
+// slice after every operation.
+func TestSequenceModel[S container.Sequence[int]](t *testing.T, newSeq func() S) {
+	t.Helper()
+	checkModel(t, func(data []byte) error { return CheckSequenceOps(newSeq(), data) })
+}
+
+// TestSetModel applies random operation sequences to sets returned by
+// newSet and compares their observable state against a plain map after
+// every operation.
+func TestSetModel[S container.Set[int]](t *testing.T, newSet func() S) {
+	t.Helper()
+	checkModel(t, func(data []byte) error { return CheckSetOps(newSet(), data) })
+}
+
+// TestMapModel applies random operation sequences to maps returned by
+// newMap and compares their observable state against a plain map after
+// every operation.
+func TestMapModel[M container.Map[int, string]](t *testing.T, newMap func() M) {
+	t.Helper()
+	checkModel(t, func(data []byte) error { return CheckMapOps(newMap(), data) })
+}
+
+// TestQueueModel applies random operation sequences to queues returned
+// by newQueue and compares their observable state against a plain slice
+// after every operation.
+func TestQueueModel[Q container.Queue[int]](t *testing.T, newQueue func() Q) {
+	t.Helper()
+	checkModel(t, func(data []byte) error { return CheckQueueOps(newQueue(), data) })
+}
+
+// TestStackModel applies random operation sequences to stacks returned
+// by newStack and compares their observable state against a plain slice
+// after every operation.
+func TestStackModel[S container.Stack[int]](t *testing.T, newStack func() S) {
+	t.Helper()
+	checkModel(t, func(data []byte) error { return CheckStackOps(newStack(), data) })
+}
+
+// TestPriorityQueueModel applies random operation sequences to priority
+// queues returned by newPQ and compares their observable state against a
+// plain slice after every operation.
+func TestPriorityQueueModel[P container.PriorityQueue[int]](t *testing.T, newPQ func(comparator.Comparator[int]) P) {
+	t.Helper()
+	checkModel(t, func(data []byte) error { return CheckPriorityQueueOps(newPQ(cmp.Compare[int]), data) })
+}
+
+func checkModel(t *testing.T, check func(data []byte) error) {
+	t.Helper()
+
+	var failure error
+	prop := func(data []byte) bool {
+		failure = check(data)
+		return failure == nil
+	}
+	if err := quick.Check(prop, quickConfig); err != nil {
+		t.Fatalf("%v\n%v", err, failure)
+	}
+}
+
+// CheckSequenceOps decodes data into a sequence of operations, applies
+// them to s and to a plain slice, and returns an error describing the
+// first divergence between the two. s must be empty.
+func CheckSequenceOps[S container.Sequence[int]](s S, data []byte) error {
+	var model []int
+	r := opReader{data: data}
+	for r.more() {
+		var got, want any
+		op := r.next() % 9
+		switch op {
+		case 0:
+			v := r.value()
+			s.PushBack(v)
+			model = append(model, v)
+		case 1:
+			got = pair(s.PopBack())
+			if len(model) == 0 {
+				want = pair(0, false)
+			} else {
+				want = pair(model[len(model)-1], true)
+				model = model[:len(model)-1]
+			}
+		case 2:
+			i := r.index(len(model) + 2)
+			got = pair(s.Get(i))
+			want = pair(0, false)
+			if i < len(model) {
+				want = pair(model[i], true)
+			}
+		case 3:
+			if len(model) == 0 {
+				continue
+			}
+			i, v := r.index(len(model)), r.value()
+			s.Set(i, v)
+			model[i] = v
+		case 4:
+			i := r.index(len(model) + 1)
+			vals := make([]int, r.index(4))
+			for j := range vals {
+				vals[j] = r.value()
+			}
+			s.Insert(i, vals...)
+			model = slices.Insert(model, i, vals...)
+		case 5:
+			if len(model) == 0 {
+				continue
+			}
+			i := r.index(len(model))
+			s.Remove(i)
+			model = slices.Delete(model, i, i+1)
+		case 6:
+			i, j := r.index(len(model)+1), r.index(len(model)+1)
+			s.RemoveRange(i, j)
+			if i < j {
+				model = slices.Delete(model, i, j)
+			}
+		case 7:
+			got = []any{pair(s.Front()), pair(s.Back())}
+			want = []any{pair(0, false), pair(0, false)}
+			if len(model) > 0 {
+				want = []any{pair(model[0], true), pair(model[len(model)-1], true)}
+			}
+		case 8:
+			s.Clear()
+			model = model[:0]
+		}
+		if err := compare(r.ops, op, got, want); err != nil {
+			return err
+		}
+		if err := compare(r.ops, op, slices.Collect(s.Values()), model); err != nil {
+			return err
+		}
+		if err := checkLen(r.ops, op, s, len(model)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckSetOps decodes data into a sequence of operations, applies them
+// to s and to a plain map, and returns an error describing the first
+// divergence between the two. s must be empty.
+func CheckSetOps[S container.Set[int]](s S, data []byte) error {
+	model := make(map[int]struct{})
+	r := opReader{data: data}
+	for r.more() {
+		var got, want any
+		op := r.next() % 4
+		switch op {
+		case 0:
+			v := r.value()
+			s.Add(v)
+			model[v] = struct{}{}
+		case 1:
+			v := r.value()
+			s.Remove(v)
+			delete(model, v)
+		case 2:
+			v := r.value()
+			_, want = model[v]
+			got = s.Contains(v)
+		case 3:
+			if r.next()%4 != 0 {
+				continue
+			}
+			s.Clear()
+			clear(model)
+		}
+		if err := compare(r.ops, op, got, want); err != nil {
+			return err
+		}
+		if err := compare(r.ops, op, sorted(s.All()), sorted(maps.Keys(model))); err != nil {
+			return err
+		}
+		if err := checkLen(r.ops, op, s, len(model)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckMapOps decodes data into a sequence of operations, applies them
+// to m and to a plain map, and returns an error describing the first
+// divergence between the two. m must be empty.
+func CheckMapOps[M container.Map[int, string]](m M, data []byte) error {
+	model := make(map[int]string)
+	r := opReader{data: data}
+	for r.more() {
+		var got, want any
+		op := r.next() % 5
+		switch op {
+		case 0:
+			k, v := r.value(), strconv.Itoa(r.value())
+			m.Insert(k, v)
+			model[k] = v
+		case 1:
+			k := r.value()
+			m.Remove(k)
+			delete(model, k)
+		case 2:
+			k := r.value()
+			got = pair(m.Get(k))
+			v, ok := model[k]
+			want = pair(v, ok)
+		case 3:
+			k := r.value()
+			_, want = model[k]
+			got = m.Contains(k)
+		case 4:
+			if r.next()%4 != 0 {
+				continue
+			}
+			m.Clear()
+			clear(model)
+		}
+		if err := compare(r.ops, op, got, want); err != nil {
+			return err
+		}
+		if err := compare(r.ops, op, maps.Collect(m.All()), model); err != nil {
+			return err
+		}
+		if err := compare(r.ops, op, sorted(m.Keys()), sorted(maps.Keys(model))); err != nil {
+			return err
+		}
+		if err := checkLen(r.ops, op, m, len(model)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckQueueOps decodes data into a sequence of operations, applies them
+// to q and to a plain slice, and returns an error describing the first
+// divergence between the two. q must be empty.
+func CheckQueueOps[Q container.Queue[int]](q Q, data []byte) error {
+	var model []int
+	r := opReader{data: data}
+	for r.more() {
+		var got, want any
+		op := r.next() % 4
+		switch op {
+		case 0:
+			v := r.value()
+			q.Push(v)
+			model = append(model, v)
+		case 1:
+			got = pair(q.Pop())
+			want = pair(0, false)
+			if len(model) > 0 {
+				want = pair(model[0], true)
+				model = model[1:]
+			}
+		case 2:
+			got = pair(q.Front())
+			want = pair(0, false)
+			if len(model) > 0 {
+				want = pair(model[0], true)
+			}
+		case 3:
+			if r.next()%4 != 0 {
+				continue
+			}
+			q.Clear()
+			model = nil
+		}
+		if err := compare(r.ops, op, got, want); err != nil {
+			return err
+		}
+		if err := checkLen(r.ops, op, q, len(model)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckStackOps decodes data into a sequence of operations, applies them
+// to s and to a plain slice, and returns an error describing the first
+// divergence between the two. s must be empty.
+func CheckStackOps[S container.Stack[int]](s S, data []byte) error {
+	var model []int
+	r := opReader{data: data}
+	for r.more() {
+		var got, want any
+		op := r.next() % 4
+		switch op {
+		case 0:
+			v := r.value()
+			s.Push(v)
+			model = append(model, v)
+		case 1:
+			got = pair(s.Pop())
+			want = pair(0, false)
+			if len(model) > 0 {
+				want = pair(model[len(model)-1], true)
+				model = model[:len(model)-1]
+			}
+		case 2:
+			got = pair(s.Top())
+			want = pair(0, false)
+			if len(model) > 0 {
+				want = pair(model[len(model)-1], true)
+			}
+		case 3:
+			if r.next()%4 != 0 {
+				continue
+			}
+			s.Clear()
+			model = nil
+		}
+		if err := compare(r.ops, op, got, want); err != nil {
+			return err
+		}
+		if err := checkLen(r.ops, op, s, len(model)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckPriorityQueueOps decodes data into a sequence of operations,
+// applies them to pq and to a plain slice, and returns an error
+// describing the first divergence between the two. pq must be empty and
+// ordered by the natural ordering of int.
+func CheckPriorityQueueOps[P container.PriorityQueue[int]](pq P, data []byte) error {
+	var model []int
+	r := opReader{data: data}
+	for r.more() {
+		var got, want any
+		op := r.next() % 4
+		switch op {
+		case 0:
+			v := r.value()
+			pq.Push(v)
+			model = append(model, v)
+		case 1:
+			got = pair(pq.Pop())
+			want = pair(0, false)
+			if len(model) > 0 {
+				i := maxIndex(model)
+				want = pair(model[i], true)
+				model = slices.Delete(model, i, i+1)
+			}
+		case 2:
+			got = pair(pq.Top())
+			want = pair(0, false)
+			if len(model) > 0 {
+				want = pair(model[maxIndex(model)], true)
+			}
+		case 3:
+			if r.next()%4 != 0 {
+				continue
+			}
+			pq.Clear()
+			model = nil
+		}
+		if err := compare(r.ops, op, got, want); err != nil {
+			return err
+		}
+		if err := checkLen(r.ops, op, pq, len(model)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// opReader decodes operation codes and operands from a byte stream.
+// Reading past the end of the stream yields zero.
+type opReader struct {
+	data []byte
+	pos  int
+	ops  int
+}
+
+func (r *opReader) more() bool {
+	if r.pos < len(r.data) {
+		r.ops++
+		return true
+	}
+	return false
+}
+
+func (r *opReader) next() int {
+	if r.pos >= len(r.data) {
+		return 0
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return int(b)
+}
+
+// value returns a small operand, so that operations often hit
+// values already in the container.
+func (r *opReader) value() int {
+	return r.next() % 32
+}
+
+// index returns an operand in the range [0, n).
+func (r *opReader) index(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return r.next() % n
+}
+
+type result struct {
+	value any
+	ok    bool
+}
+
+func pair[T any](value T, ok bool) result {
+	return result{value, ok}
+}
+
+func compare(n, op int, got, want any) error {
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		return fmt.Errorf("operation %d (code %d): got %v, want %v", n, op, got, want)
+	}
+	return nil
+}
+
+func checkLen(n, op int, c container.Container, want int) error {
+	if c.Len() != want || c.Empty() != (want == 0) {
+		return fmt.Errorf("operation %d (code %d): Len() = %d, Empty() = %t; want %d",
+			n, op, c.Len(), c.Empty(), want)
+	}
+	return nil
+}
+
+func sorted(seq iter.Seq[int]) []int {
+	s := slices.Collect(seq)
+	slices.Sort(s)
+	return s
+}
+
+func maxIndex(s []int) int {
+	return slices.Index(s, slices.Max(s))
+}
+
+// FuzzSequence runs a fuzz target that decodes its input with
+// [CheckSequenceOps] and applies it to a sequence returned by newSeq.
+func FuzzSequence[S container.Sequence[int]](f *testing.F, newSeq func() S) {
+	f.Helper()
+	fuzz(f, func(data []byte) error { return CheckSequenceOps(newSeq(), data) })
+}
+
+// FuzzSet runs a fuzz target that decodes its input with [CheckSetOps]
+// and applies it to a set returned by newSet.
+func FuzzSet[S container.Set[int]](f *testing.F, newSet func() S) {
+	f.Helper()
+	fuzz(f, func(data []byte) error { return CheckSetOps(newSet(), data) })
+}
+
+// FuzzMap runs a fuzz target that decodes its input with [CheckMapOps]
+// and applies it to a map returned by newMap.
+func FuzzMap[M container.Map[int, string]](f *testing.F, newMap func() M) {
+	f.Helper()
+	fuzz(f, func(data []byte) error { return CheckMapOps(newMap(), data) })
+}
+
+// FuzzQueue runs a fuzz target that decodes its input with
+// [CheckQueueOps] and applies it to a queue returned by newQueue.
+func FuzzQueue[Q container.Queue[int]](f *testing.F, newQueue func() Q) {
+	f.Helper()
+	fuzz(f, func(data []byte) error { return CheckQueueOps(newQueue(), data) })
+}
+
+// FuzzStack runs a fuzz target that decodes its input with
+// [CheckStackOps] and applies it to a stack returned by newStack.
+func FuzzStack[S container.Stack[int]](f *testing.F, newStack func() S) {
+	f.Helper()
+	fuzz(f, func(data []byte) error { return CheckStackOps(newStack(), data) })
+}
+
+// FuzzPriorityQueue runs a fuzz target that decodes its input with
+// [CheckPriorityQueueOps] and applies it to a priority queue returned by
+// newPQ.
+func FuzzPriorityQueue[P container.PriorityQueue[int]](f *testing.F, newPQ func(comparator.Comparator[int]) P) {
+	f.Helper()
+	fuzz(f, func(data []byte) error { return CheckPriorityQueueOps(newPQ(cmp.Compare[int]), data) })
+}
+
+func fuzz(f *testing.F, check func(data []byte) error) {
+	f.Helper()
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := check(data); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
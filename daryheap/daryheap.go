@@ -0,0 +1,195 @@
+// Package daryheap provides a generic priority queue implementation based
+// on a d-ary array heap.
+//
+// A d-ary heap is shallower than a binary heap, so pushes are cheaper and
+// pops compare more elements per level, which are adjacent in memory.
+// Arities of 4 to 8 often perform better than 2 in practice.
+package daryheap
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
+)
+
+// DefaultArity is the arity of heaps created with [New].
+const DefaultArity = 4
+
+// DaryHeap is a generic priority queue with a configurable comparision
+// function (comparator) and arity.
+type DaryHeap[T any] struct {
+	data       []T
+	comparator comparator.Comparator[T]
+	arity      int
+}
+
+var _ container.PriorityQueue[int] = (*DaryHeap[int])(nil)
+
+// New creates a new [DaryHeap] with the specified comparator and
+// [DefaultArity].
+func New[T any](comparator comparator.Comparator[T]) *DaryHeap[T] {
+	return NewWithArity(comparator, DefaultArity)
+}
+
+// NewWithArity creates a new [DaryHeap] with the specified comparator in
+// which each node has up to arity children.
+//
+// If arity is less than 2, NewWithArity panics.
+func NewWithArity[T any](comparator comparator.Comparator[T], arity int) *DaryHeap[T] {
+	if arity < 2 {
+		panic("daryheap.NewWithArity: arity less than 2")
+	}
+	return &DaryHeap[T]{
+		data:       []T{},
+		comparator: comparator,
+		arity:      arity,
+	}
+}
+
+// Collect collects values from an iterator into a new [DaryHeap] ordered
+// by comparator with [DefaultArity]. It builds the heap in O(n) time.
+func Collect[T any](seq iter.Seq[T], comparator comparator.Comparator[T]) *DaryHeap[T] {
+	h := New(comparator)
+	h.data = slices.AppendSeq(h.data, seq)
+	h.heapify()
+	return h
+}
+
+// Arity returns the maximum number of children of each node.
+func (h *DaryHeap[T]) Arity() int {
+	return h.arity
+}
+
+// Len returns the number of elements in the heap.
+func (h *DaryHeap[T]) Len() int {
+	return len(h.data)
+}
+
+// Empty reports whether the heap is empty.
+func (h *DaryHeap[T]) Empty() bool {
+	return len(h.data) == 0
+}
+
+// Clear removes all elements from the heap.
+func (h *DaryHeap[T]) Clear() {
+	clear(h.data)
+	h.data = h.data[:0]
+}
+
+// Push adds an element to the heap.
+func (h *DaryHeap[T]) Push(v T) {
+	h.data = append(h.data, v)
+	h.siftUp(len(h.data) - 1)
+}
+
+// Top returns the element with the maximal priority in the heap.
+// If the heap is empty, it returns the zero value of the element type
+// and false.
+func (h *DaryHeap[T]) Top() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[0], true
+}
+
+// Pop returns the element with the maximal priority in the heap, and
+// removes it from the heap. If the heap is empty, it returns the zero
+// value of the element type and false.
+func (h *DaryHeap[T]) Pop() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	top := h.data[0]
+	last := len(h.data) - 1
+	h.data[0] = h.data[last]
+	var zero T
+	h.data[last] = zero
+	h.data = h.data[:last]
+	h.siftDown(0)
+	return top, true
+}
+
+// Meld moves all elements of other into h, leaving other empty.
+//
+// The elements are ordered by the comparator of h. Melding takes
+// O(m log n) time for m elements into a heap of n, or O(n + m) when m is
+// large enough that rebuilding the heap is cheaper.
+func (h *DaryHeap[T]) Meld(other *DaryHeap[T]) {
+	if h == other {
+		return
+	}
+	n := len(h.data)
+	h.data = append(h.data, other.data...)
+	other.Clear()
+	if len(h.data)-n > n/2 {
+		h.heapify()
+		return
+	}
+	for i := n; i < len(h.data); i++ {
+		h.siftUp(i)
+	}
+}
+
+// All returns an iterator over the elements in the heap in unspecified
+// order.
+func (h *DaryHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range h.data {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (h *DaryHeap[T]) heapify() {
+	if len(h.data) < 2 {
+		return
+	}
+	for i := (len(h.data) - 2) / h.arity; i >= 0; i-- {
+		h.siftDown(i)
+	}
+}
+
+func (h *DaryHeap[T]) siftUp(index int) {
+	v := h.data[index]
+	for index > 0 {
+		parent := (index - 1) / h.arity
+		if h.comparator(v, h.data[parent]) <= 0 {
+			break
+		}
+		h.data[index] = h.data[parent]
+		index = parent
+	}
+	h.data[index] = v
+}
+
+func (h *DaryHeap[T]) siftDown(index int) {
+	n := len(h.data)
+	if n == 0 {
+		return
+	}
+	v := h.data[index]
+	for {
+		first := h.arity*index + 1
+		if first >= n {
+			break
+		}
+		best := first
+		for c := first + 1; c < min(first+h.arity, n); c++ {
+			if h.comparator(h.data[c], h.data[best]) > 0 {
+				best = c
+			}
+		}
+		if h.comparator(h.data[best], v) <= 0 {
+			break
+		}
+		h.data[index] = h.data[best]
+		index = best
+	}
+	h.data[index] = v
+}
@@ -0,0 +1,47 @@
+package daryheap
+
+import (
+	"cmp"
+	"testing"
+	"testing/quick"
+)
+
+// isHeap reports whether h.data satisfies the heap invariant: no element
+// has a higher priority than its parent.
+func (h *DaryHeap[T]) isHeap() bool {
+	for i := 1; i < len(h.data); i++ {
+		if h.comparator(h.data[i], h.data[(i-1)/h.arity]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestHeapInvariant(t *testing.T) {
+	prop := func(ops []int8, arity uint8) bool {
+		h := NewWithArity(cmp.Compare[int8], int(arity%7)+2)
+		for _, op := range ops {
+			switch {
+			case op < 0 && op%3 == 0:
+				h.Pop()
+			case op < 0 && op%3 == -1:
+				other := NewWithArity(cmp.Compare[int8], 3)
+				other.data = append(other.data, ops...)
+				other.heapify()
+				if !other.isHeap() {
+					return false
+				}
+				h.Meld(other)
+			default:
+				h.Push(op)
+			}
+			if !h.isHeap() {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,73 @@
+package daryheap_test
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/daryheap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaryHeapArities(t *testing.T) {
+	nums := rand.Perm(1000)
+	want := slices.Sorted(slices.Values(nums))
+	slices.Reverse(want)
+	for arity := 2; arity <= 9; arity++ {
+		h := daryheap.NewWithArity(cmp.Compare[int], arity)
+		assert.Equal(t, arity, h.Arity())
+		for _, v := range nums {
+			h.Push(v)
+		}
+		var got []int
+		for !h.Empty() {
+			v, _ := h.Pop()
+			got = append(got, v)
+		}
+		assert.Equal(t, want, got)
+	}
+
+	assert.PanicsWithValue(t, "daryheap.NewWithArity: arity less than 2", func() {
+		daryheap.NewWithArity(cmp.Compare[int], 1)
+	})
+}
+
+func TestMeld(t *testing.T) {
+	h1 := daryheap.Collect(slices.Values([]int{1, 5, 3}), cmp.Compare[int])
+	h2 := daryheap.Collect(slices.Values([]int{4, 6}), cmp.Compare[int])
+	h1.Meld(h2)
+	h1.Meld(h1)
+	assert.True(t, h2.Empty())
+	assert.Equal(t, 5, h1.Len())
+	assert.ElementsMatch(t, []int{1, 3, 4, 5, 6}, slices.Collect(h1.All()))
+
+	h3 := daryheap.Collect(slices.Values([]int{0}), cmp.Compare[int])
+	h1.Meld(h3)
+	var got []int
+	for !h1.Empty() {
+		v, _ := h1.Pop()
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{6, 5, 4, 3, 1, 0}, got)
+}
+
+func newBinary(cmp comparator.Comparator[int]) *daryheap.DaryHeap[int] {
+	return daryheap.NewWithArity(cmp, 2)
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestPriorityQueue(t, daryheap.New[int])
+	containertest.TestPriorityQueue(t, newBinary)
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestPriorityQueueModel(t, daryheap.New[int])
+	containertest.TestPriorityQueueModel(t, newBinary)
+}
+
+func FuzzDaryHeap(f *testing.F) {
+	containertest.FuzzPriorityQueue(f, daryheap.New[int])
+}
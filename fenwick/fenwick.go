@@ -0,0 +1,152 @@
+// Package fenwick provides Fenwick trees, also known as binary indexed
+// trees, which maintain prefix sums of a sequence of numbers under point
+// updates in O(log n) time using no more memory than the numbers
+// themselves.
+package fenwick
+
+import "math/bits"
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// FenwickTree maintains the prefix sums of a fixed number of elements.
+type FenwickTree[T Number] struct {
+	// tree is one-indexed: tree[i] holds the sum of the elements with
+	// one-based indices in (i-lowbit(i), i].
+	tree []T
+}
+
+// New creates a new [FenwickTree] with n elements, all set to zero.
+func New[T Number](n int) *FenwickTree[T] {
+	return &FenwickTree[T]{tree: make([]T, n+1)}
+}
+
+// FromSlice creates a new [FenwickTree] with the elements of values in
+// O(n) time. values is not retained.
+func FromSlice[T Number](values []T) *FenwickTree[T] {
+	tree := make([]T, len(values)+1)
+	copy(tree[1:], values)
+	build(tree)
+	return &FenwickTree[T]{tree: tree}
+}
+
+// Len returns the number of elements in the tree.
+func (t *FenwickTree[T]) Len() int {
+	return len(t.tree) - 1
+}
+
+// Add adds delta to the zero-indexed ith element of t in O(log n) time.
+//
+// Add panics if i is negative or greater than or equal to the length of t.
+func (t *FenwickTree[T]) Add(i int, delta T) {
+	if i < 0 || i >= t.Len() {
+		panic("fenwick.Add: index out of range")
+	}
+	for i++; i < len(t.tree); i += lowbit(i) {
+		t.tree[i] += delta
+	}
+}
+
+// PrefixSum returns the sum of the first n elements of t in O(log n)
+// time.
+//
+// PrefixSum panics if n is negative or greater than the length of t.
+func (t *FenwickTree[T]) PrefixSum(n int) T {
+	if n < 0 || n > t.Len() {
+		panic("fenwick.PrefixSum: index out of range")
+	}
+	return t.prefixSum(n)
+}
+
+// RangeSum returns the sum of the elements with indices in [l, r) in
+// O(log n) time.
+//
+// RangeSum panics if l < 0, r > Len or l > r.
+func (t *FenwickTree[T]) RangeSum(l, r int) T {
+	if l < 0 || r > t.Len() || l > r {
+		panic("fenwick.RangeSum: invalid range")
+	}
+	return t.prefixSum(r) - t.prefixSum(l)
+}
+
+// Get returns the zero-indexed ith element of t, if any, in O(log n)
+// time.
+func (t *FenwickTree[T]) Get(i int) (T, bool) {
+	if i < 0 || i >= t.Len() {
+		var zero T
+		return zero, false
+	}
+	i++
+	// The sum stored at i covers the elements after parent, so subtract
+	// the sums covering the elements between parent and i.
+	v := t.tree[i]
+	for j, parent := i-1, i-lowbit(i); j > parent; j -= lowbit(j) {
+		v -= t.tree[j]
+	}
+	return v, true
+}
+
+// Set sets the zero-indexed ith element of t to value in O(log n) time.
+//
+// Set panics if i is negative or greater than or equal to the length of t.
+func (t *FenwickTree[T]) Set(i int, value T) {
+	old, ok := t.Get(i)
+	if !ok {
+		panic("fenwick.Set: index out of range")
+	}
+	t.Add(i, value-old)
+}
+
+// LowerBound returns the smallest index i such that the sum of the
+// elements with indices in [0, i] is at least sum, or Len if there is
+// none, in O(log n) time. It returns 0 if sum is not positive.
+//
+// The elements of t must not be negative.
+func (t *FenwickTree[T]) LowerBound(sum T) int {
+	if sum <= 0 {
+		return 0
+	}
+	pos := 0
+	for step := highbit(t.Len()); step > 0; step /= 2 {
+		if next := pos + step; next < len(t.tree) && t.tree[next] < sum {
+			pos = next
+			sum -= t.tree[pos]
+		}
+	}
+	return pos
+}
+
+func (t *FenwickTree[T]) prefixSum(n int) T {
+	var sum T
+	for ; n > 0; n -= lowbit(n) {
+		sum += t.tree[n]
+	}
+	return sum
+}
+
+// build turns tree, a one-indexed slice of elements, into a Fenwick tree
+// in O(n) time.
+func build[T Number](tree []T) {
+	for i := 1; i < len(tree); i++ {
+		if j := i + lowbit(i); j < len(tree) {
+			tree[j] += tree[i]
+		}
+	}
+}
+
+// lowbit returns the lowest set bit of i.
+func lowbit(i int) int {
+	return i & -i
+}
+
+// highbit returns the highest set bit of n, or 0 if n is 0.
+func highbit(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return 1 << (bits.Len(uint(n)) - 1)
+}
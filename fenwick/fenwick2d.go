@@ -0,0 +1,139 @@
+package fenwick
+
+// FenwickTree2D maintains the prefix sums of a fixed-size matrix of
+// elements.
+type FenwickTree2D[T Number] struct {
+	// tree is a one-indexed (rows+1)×(cols+1) matrix in row-major order:
+	// tree[i][j] holds the sum of the elements with one-based indices in
+	// (i-lowbit(i), i]×(j-lowbit(j), j].
+	tree       []T
+	rows, cols int
+}
+
+// New2D creates a new [FenwickTree2D] with rows×cols elements, all set to
+// zero.
+func New2D[T Number](rows, cols int) *FenwickTree2D[T] {
+	return &FenwickTree2D[T]{
+		tree: make([]T, (rows+1)*(cols+1)),
+		rows: rows,
+		cols: cols,
+	}
+}
+
+// FromSlice2D creates a new [FenwickTree2D] with the elements of values,
+// indexed by row and then column, in O(rows×cols) time. values is not
+// retained.
+//
+// If the rows of values differ in length, FromSlice2D panics.
+func FromSlice2D[T Number](values [][]T) *FenwickTree2D[T] {
+	var cols int
+	if len(values) > 0 {
+		cols = len(values[0])
+	}
+	t := New2D[T](len(values), cols)
+	for i, row := range values {
+		if len(row) != cols {
+			panic("fenwick.FromSlice2D: rows of different lengths")
+		}
+		r := t.row(i + 1)
+		copy(r[1:], row)
+		build(r)
+	}
+	// Combine whole rows, like build does for single elements.
+	for i := 1; i <= t.rows; i++ {
+		if j := i + lowbit(i); j <= t.rows {
+			dst, src := t.row(j), t.row(i)
+			for k := range dst {
+				dst[k] += src[k]
+			}
+		}
+	}
+	return t
+}
+
+// Rows returns the number of rows in the tree.
+func (t *FenwickTree2D[T]) Rows() int {
+	return t.rows
+}
+
+// Cols returns the number of columns in the tree.
+func (t *FenwickTree2D[T]) Cols() int {
+	return t.cols
+}
+
+// Add adds delta to the element at row r and column c in
+// O(log rows × log cols) time.
+//
+// Add panics if r or c is out of range.
+func (t *FenwickTree2D[T]) Add(r, c int, delta T) {
+	if r < 0 || r >= t.rows || c < 0 || c >= t.cols {
+		panic("fenwick.Add: index out of range")
+	}
+	for i := r + 1; i <= t.rows; i += lowbit(i) {
+		row := t.row(i)
+		for j := c + 1; j <= t.cols; j += lowbit(j) {
+			row[j] += delta
+		}
+	}
+}
+
+// PrefixSum returns the sum of the elements in the first r rows and the
+// first c columns in O(log rows × log cols) time.
+//
+// PrefixSum panics if r or c is negative or greater than the number of
+// rows or columns respectively.
+func (t *FenwickTree2D[T]) PrefixSum(r, c int) T {
+	if r < 0 || r > t.rows || c < 0 || c > t.cols {
+		panic("fenwick.PrefixSum: index out of range")
+	}
+	return t.prefixSum(r, c)
+}
+
+// RangeSum returns the sum of the elements with row indices in [r1, r2)
+// and column indices in [c1, c2) in O(log rows × log cols) time.
+//
+// RangeSum panics if either range is invalid.
+func (t *FenwickTree2D[T]) RangeSum(r1, c1, r2, c2 int) T {
+	if r1 < 0 || r2 > t.rows || r1 > r2 || c1 < 0 || c2 > t.cols || c1 > c2 {
+		panic("fenwick.RangeSum: invalid range")
+	}
+	return t.prefixSum(r2, c2) - t.prefixSum(r1, c2) - t.prefixSum(r2, c1) + t.prefixSum(r1, c1)
+}
+
+// Get returns the element at row r and column c, if any, in
+// O(log rows × log cols) time.
+func (t *FenwickTree2D[T]) Get(r, c int) (T, bool) {
+	if r < 0 || r >= t.rows || c < 0 || c >= t.cols {
+		var zero T
+		return zero, false
+	}
+	return t.RangeSum(r, c, r+1, c+1), true
+}
+
+// Set sets the element at row r and column c to value in
+// O(log rows × log cols) time.
+//
+// Set panics if r or c is out of range.
+func (t *FenwickTree2D[T]) Set(r, c int, value T) {
+	old, ok := t.Get(r, c)
+	if !ok {
+		panic("fenwick.Set: index out of range")
+	}
+	t.Add(r, c, value-old)
+}
+
+func (t *FenwickTree2D[T]) prefixSum(r, c int) T {
+	var sum T
+	for i := r; i > 0; i -= lowbit(i) {
+		row := t.row(i)
+		for j := c; j > 0; j -= lowbit(j) {
+			sum += row[j]
+		}
+	}
+	return sum
+}
+
+// row returns the one-indexed row i of the tree.
+func (t *FenwickTree2D[T]) row(i int) []T {
+	return t.tree[i*(t.cols+1) : (i+1)*(t.cols+1)]
+}
@@ -0,0 +1,183 @@
+package fenwick_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/fenwick"
+)
+
+func TestFenwickTree(t *testing.T) {
+	ft := fenwick.FromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6})
+	assert.Equal(t, 8, ft.Len())
+	assert.Equal(t, 0, ft.PrefixSum(0))
+	assert.Equal(t, 8, ft.PrefixSum(3))
+	assert.Equal(t, 31, ft.PrefixSum(8))
+	assert.Equal(t, 19, ft.RangeSum(2, 6))
+	assert.Equal(t, 0, ft.RangeSum(4, 4))
+
+	ft.Add(2, 10)
+	assert.Equal(t, 29, ft.RangeSum(2, 6))
+	v, ok := ft.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, 14, v)
+
+	ft.Set(2, 4)
+	v, _ = ft.Get(2)
+	assert.Equal(t, 4, v)
+	assert.Equal(t, 31, ft.PrefixSum(8))
+
+	_, ok = ft.Get(8)
+	assert.False(t, ok)
+}
+
+func TestFenwickTreeLowerBound(t *testing.T) {
+	ft := fenwick.FromSlice([]uint{3, 0, 4, 1, 5})
+	assert.Equal(t, 0, ft.LowerBound(0))
+	assert.Equal(t, 0, ft.LowerBound(1))
+	assert.Equal(t, 0, ft.LowerBound(3))
+	assert.Equal(t, 2, ft.LowerBound(4))
+	assert.Equal(t, 2, ft.LowerBound(7))
+	assert.Equal(t, 3, ft.LowerBound(8))
+	assert.Equal(t, 4, ft.LowerBound(13))
+	assert.Equal(t, 5, ft.LowerBound(14))
+
+	assert.Equal(t, 0, fenwick.New[float64](0).LowerBound(1))
+}
+
+func TestFenwickTreeModel(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for n := range 50 {
+		model := make([]int, n)
+		for i := range model {
+			model[i] = r.IntN(10)
+		}
+		ft := fenwick.FromSlice(model)
+		if n == 0 {
+			assert.Equal(t, 0, ft.PrefixSum(0))
+			continue
+		}
+		for range 200 {
+			i, v := r.IntN(n), r.IntN(10)
+			switch r.IntN(4) {
+			case 0:
+				ft.Add(i, v)
+				model[i] += v
+			case 1:
+				ft.Set(i, v)
+				model[i] = v
+			case 2:
+				l := r.IntN(n + 1)
+				h := l + r.IntN(n-l+1)
+				want := 0
+				for _, x := range model[l:h] {
+					want += x
+				}
+				assert.Equal(t, want, ft.RangeSum(l, h))
+			case 3:
+				sum, want := 0, n
+				for j, x := range model {
+					if sum += x; sum >= v {
+						want = j
+						break
+					}
+				}
+				if v == 0 {
+					want = 0
+				}
+				assert.Equal(t, want, ft.LowerBound(v))
+			}
+			got, _ := ft.Get(i)
+			assert.Equal(t, model[i], got)
+		}
+	}
+}
+
+func TestFenwickTree2D(t *testing.T) {
+	ft := fenwick.FromSlice2D([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	assert.Equal(t, 2, ft.Rows())
+	assert.Equal(t, 3, ft.Cols())
+	assert.Equal(t, 21, ft.PrefixSum(2, 3))
+	assert.Equal(t, 12, ft.PrefixSum(2, 2))
+	assert.Equal(t, 9, ft.RangeSum(1, 0, 2, 2))
+	assert.Equal(t, 3, ft.RangeSum(0, 2, 1, 3))
+
+	ft.Add(1, 1, 10)
+	assert.Equal(t, 21, ft.RangeSum(1, 1, 2, 3))
+	ft.Set(0, 0, 7)
+	v, ok := ft.Get(0, 0)
+	assert.True(t, ok)
+	assert.Equal(t, 7, v)
+	_, ok = ft.Get(2, 0)
+	assert.False(t, ok)
+
+	assert.PanicsWithValue(t, "fenwick.FromSlice2D: rows of different lengths", func() {
+		fenwick.FromSlice2D([][]int{{1}, {2, 3}})
+	})
+}
+
+func TestFenwickTree2DModel(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 20 {
+		rows, cols := r.IntN(10)+1, r.IntN(10)+1
+		model := make([][]float64, rows)
+		for i := range model {
+			model[i] = make([]float64, cols)
+			for j := range model[i] {
+				model[i][j] = float64(r.IntN(10))
+			}
+		}
+		ft := fenwick.FromSlice2D(model)
+		for range 200 {
+			i, j, v := r.IntN(rows), r.IntN(cols), float64(r.IntN(10))
+			switch r.IntN(3) {
+			case 0:
+				ft.Add(i, j, v)
+				model[i][j] += v
+			case 1:
+				ft.Set(i, j, v)
+				model[i][j] = v
+			case 2:
+				r1 := r.IntN(rows + 1)
+				r2 := r1 + r.IntN(rows-r1+1)
+				c1 := r.IntN(cols + 1)
+				c2 := c1 + r.IntN(cols-c1+1)
+				want := 0.0
+				for _, row := range model[r1:r2] {
+					for _, x := range row[c1:c2] {
+						want += x
+					}
+				}
+				assert.Equal(t, want, ft.RangeSum(r1, c1, r2, c2))
+			}
+		}
+	}
+}
+
+func TestPanics(t *testing.T) {
+	ft := fenwick.New[int](3)
+	assert.PanicsWithValue(t, "fenwick.Add: index out of range", func() { ft.Add(3, 1) })
+	assert.PanicsWithValue(t, "fenwick.Set: index out of range", func() { ft.Set(-1, 1) })
+	assert.PanicsWithValue(t, "fenwick.PrefixSum: index out of range", func() { ft.PrefixSum(4) })
+	assert.PanicsWithValue(t, "fenwick.RangeSum: invalid range", func() { ft.RangeSum(2, 1) })
+
+	ft2 := fenwick.New2D[int](2, 2)
+	assert.PanicsWithValue(t, "fenwick.Add: index out of range", func() { ft2.Add(0, 2, 1) })
+	assert.PanicsWithValue(t, "fenwick.PrefixSum: index out of range", func() { ft2.PrefixSum(3, 0) })
+	assert.PanicsWithValue(t, "fenwick.RangeSum: invalid range", func() { ft2.RangeSum(0, 2, 1, 1) })
+}
+
+func BenchmarkFenwickTree(b *testing.B) {
+	const n = 1 << 16
+	ft := fenwick.New[int](n)
+	r := rand.New(rand.NewPCG(1, 2))
+	for range b.N {
+		ft.Add(r.IntN(n), 1)
+		ft.PrefixSum(r.IntN(n + 1))
+	}
+}
@@ -0,0 +1,354 @@
+// Package fibonacciheap provides a generic priority queue implementation
+// based on a Fibonacci heap.
+//
+// A Fibonacci heap supports pushing, melding and increasing the priority
+// of an element in O(1) amortized time, and popping in O(log n) amortized
+// time, which gives the best known bounds for algorithms with many
+// decrease-key operations, such as Dijkstra's shortest paths.
+package fibonacciheap
+
+import (
+	"iter"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
+)
+
+// Element is a handle to an element of a [FibonacciHeap], used to update
+// or remove it.
+type Element[T any] struct {
+	value T
+	// left and right link the element into a circular list of siblings.
+	parent, child, left, right *Element[T]
+	degree                     int
+	// mark is set when the element has lost a child since it became the
+	// child of its parent.
+	mark   bool
+	inHeap bool
+}
+
+// Value returns the value of the element.
+func (e *Element[T]) Value() T {
+	return e.value
+}
+
+// FibonacciHeap is a generic priority queue with a configurable
+// comparision function (comparator).
+type FibonacciHeap[T any] struct {
+	// root is the element with the maximal priority in the circular list
+	// of roots.
+	root       *Element[T]
+	len        int
+	comparator comparator.Comparator[T]
+}
+
+var _ container.PriorityQueue[int] = (*FibonacciHeap[int])(nil)
+
+// New creates a new [FibonacciHeap] with the specified comparator.
+func New[T any](comparator comparator.Comparator[T]) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{comparator: comparator}
+}
+
+// Collect collects values from an iterator into a new [FibonacciHeap]
+// ordered by comparator.
+func Collect[T any](seq iter.Seq[T], comparator comparator.Comparator[T]) *FibonacciHeap[T] {
+	h := New(comparator)
+	for v := range seq {
+		h.Push(v)
+	}
+	return h
+}
+
+// Len returns the number of elements in the heap.
+func (h *FibonacciHeap[T]) Len() int {
+	return h.len
+}
+
+// Empty reports whether the heap is empty.
+func (h *FibonacciHeap[T]) Empty() bool {
+	return h.len == 0
+}
+
+// Clear removes all elements from the heap. The elements previously in
+// the heap must not be used afterwards.
+func (h *FibonacciHeap[T]) Clear() {
+	h.root = nil
+	h.len = 0
+}
+
+// Push adds an element to the heap.
+func (h *FibonacciHeap[T]) Push(v T) {
+	h.Insert(v)
+}
+
+// Insert adds an element to the heap and returns a handle to it.
+func (h *FibonacciHeap[T]) Insert(v T) *Element[T] {
+	e := &Element[T]{value: v, inHeap: true}
+	e.left, e.right = e, e
+	h.addRoot(e)
+	h.len++
+	return e
+}
+
+// Top returns the element with the maximal priority in the heap.
+// If the heap is empty, it returns the zero value of the element type
+// and false.
+func (h *FibonacciHeap[T]) Top() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// TopElement returns a handle to the element with the maximal priority
+// in the heap, or nil if the heap is empty.
+func (h *FibonacciHeap[T]) TopElement() *Element[T] {
+	return h.root
+}
+
+// Pop returns the element with the maximal priority in the heap, and
+// removes it from the heap. If the heap is empty, it returns the zero
+// value of the element type and false.
+func (h *FibonacciHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	e := h.root
+	h.popRoot()
+	return e.value, true
+}
+
+// Update changes the value of e, which must be in h, and restores the
+// heap order.
+//
+// Raising the priority of e takes O(1) amortized time and lowering it
+// O(log n) amortized time.
+//
+// If e is not in the heap, Update panics.
+func (h *FibonacciHeap[T]) Update(e *Element[T], v T) {
+	if !e.inHeap {
+		panic("fibonacciheap.Update: element not in heap")
+	}
+	if h.comparator(v, e.value) < 0 {
+		// The children of e may now have a higher priority than e, so
+		// reinsert e on its own.
+		h.remove(e)
+		e.value = v
+		e.inHeap = true
+		h.addRoot(e)
+		h.len++
+		return
+	}
+	e.value = v
+	if p := e.parent; p != nil && h.comparator(v, p.value) > 0 {
+		h.cut(e)
+		h.cascadingCut(p)
+	}
+	if h.comparator(v, h.root.value) > 0 {
+		h.root = e
+	}
+}
+
+// Remove removes e, which must be in h, from the heap in O(log n)
+// amortized time.
+//
+// If e is not in the heap, Remove panics.
+func (h *FibonacciHeap[T]) Remove(e *Element[T]) {
+	if !e.inHeap {
+		panic("fibonacciheap.Remove: element not in heap")
+	}
+	h.remove(e)
+}
+
+// Meld moves all elements of other into h in O(1) time, leaving other
+// empty. The handles of the elements of other remain valid for h.
+//
+// The subtrees of other are linked into h as they are, so other must order
+// elements the same way as h, for example by having been created with the
+// same comparator. Otherwise the order in which h pops elements is
+// unspecified.
+func (h *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) {
+	if h == other || other.root == nil {
+		return
+	}
+	if h.root == nil {
+		h.root = other.root
+	} else {
+		splice(h.root, other.root)
+		if h.comparator(other.root.value, h.root.value) > 0 {
+			h.root = other.root
+		}
+	}
+	h.len += other.len
+	other.root = nil
+	other.len = 0
+}
+
+// All returns an iterator over the elements in the heap in unspecified
+// order.
+func (h *FibonacciHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if h.root == nil {
+			return
+		}
+		stack := []*Element[T]{h.root}
+		for len(stack) > 0 {
+			first := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			e := first
+			for {
+				if !yield(e.value) {
+					return
+				}
+				if e.child != nil {
+					stack = append(stack, e.child)
+				}
+				if e = e.right; e == first {
+					break
+				}
+			}
+		}
+	}
+}
+
+// splice joins the circular lists containing a and b.
+func splice[T any](a, b *Element[T]) {
+	ar, bl := a.right, b.left
+	a.right, b.left = b, a
+	bl.right, ar.left = ar, bl
+}
+
+// unlink removes e from its circular list.
+func unlink[T any](e *Element[T]) {
+	e.left.right = e.right
+	e.right.left = e.left
+	e.left, e.right = e, e
+}
+
+// addRoot adds e, which is a singleton list, to the roots.
+func (h *FibonacciHeap[T]) addRoot(e *Element[T]) {
+	e.parent = nil
+	e.mark = false
+	if h.root == nil {
+		h.root = e
+		return
+	}
+	splice(h.root, e)
+	if h.comparator(e.value, h.root.value) > 0 {
+		h.root = e
+	}
+}
+
+// remove removes e from the heap by cutting it from its parent, making it
+// the root and popping it.
+func (h *FibonacciHeap[T]) remove(e *Element[T]) {
+	if p := e.parent; p != nil {
+		h.cut(e)
+		h.cascadingCut(p)
+	}
+	h.root = e
+	h.popRoot()
+}
+
+// popRoot removes h.root from the heap and consolidates the roots.
+func (h *FibonacciHeap[T]) popRoot() {
+	e := h.root
+	for c := e.child; c != nil; c = e.child {
+		h.removeChild(e, c)
+		splice(e, c)
+	}
+	next := e.right
+	unlink(e)
+	e.child, e.degree, e.mark, e.inHeap = nil, 0, false, false
+	h.len--
+	if next == e {
+		h.root = nil
+		return
+	}
+	h.root = next
+	h.consolidate()
+}
+
+// consolidate links roots of equal degree until all degrees differ, and
+// finds the new root with the maximal priority.
+func (h *FibonacciHeap[T]) consolidate() {
+	var roots []*Element[T]
+	for e := h.root; ; {
+		roots = append(roots, e)
+		if e = e.right; e == h.root {
+			break
+		}
+	}
+
+	var byDegree []*Element[T]
+	for _, x := range roots {
+		unlink(x)
+		for {
+			for len(byDegree) <= x.degree {
+				byDegree = append(byDegree, nil)
+			}
+			y := byDegree[x.degree]
+			if y == nil {
+				break
+			}
+			byDegree[x.degree] = nil
+			if h.comparator(y.value, x.value) > 0 {
+				x, y = y, x
+			}
+			h.link(y, x)
+		}
+		byDegree[x.degree] = x
+	}
+
+	h.root = nil
+	for _, x := range byDegree {
+		if x != nil {
+			h.addRoot(x)
+		}
+	}
+}
+
+// link makes the root y a child of the root x.
+func (h *FibonacciHeap[T]) link(y, x *Element[T]) {
+	y.parent = x
+	y.mark = false
+	if x.child == nil {
+		x.child = y
+	} else {
+		splice(x.child, y)
+	}
+	x.degree++
+}
+
+// removeChild unlinks the child c from the children of p.
+func (h *FibonacciHeap[T]) removeChild(p, c *Element[T]) {
+	if p.child == c {
+		p.child = c.right
+		if p.child == c {
+			p.child = nil
+		}
+	}
+	unlink(c)
+	c.parent = nil
+	p.degree--
+}
+
+// cut moves e from the children of its parent to the roots.
+func (h *FibonacciHeap[T]) cut(e *Element[T]) {
+	h.removeChild(e.parent, e)
+	h.addRoot(e)
+}
+
+// cascadingCut cuts the ancestors of e that have lost a second child,
+// starting at e.
+func (h *FibonacciHeap[T]) cascadingCut(e *Element[T]) {
+	for p := e.parent; p != nil; e, p = p, p.parent {
+		if !e.mark {
+			e.mark = true
+			return
+		}
+		h.cut(e)
+	}
+}
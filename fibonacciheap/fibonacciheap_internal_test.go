@@ -0,0 +1,92 @@
+package fibonacciheap
+
+import (
+	"cmp"
+	"testing"
+	"testing/quick"
+)
+
+// isValid reports whether the trees of h are heap-ordered, their links
+// and degrees are consistent, h.root has the maximal priority among the
+// roots and the trees hold h.len elements.
+func (h *FibonacciHeap[T]) isValid() bool {
+	if h.root == nil {
+		return h.len == 0
+	}
+	n := 0
+	var check func(first, parent *Element[T]) bool
+	check = func(first, parent *Element[T]) bool {
+		e := first
+		for {
+			n++
+			if !e.inHeap || e.parent != parent || e.right.left != e || e.left.right != e {
+				return false
+			}
+			if parent == nil && h.comparator(e.value, h.root.value) > 0 {
+				return false
+			}
+			if parent != nil && h.comparator(e.value, parent.value) > 0 {
+				return false
+			}
+			degree := 0
+			if e.child != nil {
+				for c := e.child; ; {
+					degree++
+					if c = c.right; c == e.child {
+						break
+					}
+				}
+				if !check(e.child, e) {
+					return false
+				}
+			}
+			if degree != e.degree {
+				return false
+			}
+			if e = e.right; e == first {
+				return true
+			}
+		}
+	}
+	return check(h.root, nil) && n == h.len
+}
+
+func TestHeapInvariant(t *testing.T) {
+	prop := func(ops []int8) bool {
+		h := New(cmp.Compare[int8])
+		var elems []*Element[int8]
+		for i, op := range ops {
+			switch {
+			case op < 0 && op%3 == 0:
+				if e := h.TopElement(); e != nil {
+					h.Pop()
+					elems = remove(elems, e)
+				}
+			case op < 0 && op%3 == -1 && len(elems) > 0:
+				e := elems[i%len(elems)]
+				h.Remove(e)
+				elems = remove(elems, e)
+			case op%2 == 1 && len(elems) > 0:
+				h.Update(elems[i%len(elems)], op)
+			default:
+				elems = append(elems, h.Insert(op))
+			}
+			if !h.isValid() {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(prop, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func remove[T any](elems []*Element[T], e *Element[T]) []*Element[T] {
+	for i, x := range elems {
+		if x == e {
+			return append(elems[:i], elems[i+1:]...)
+		}
+	}
+	return elems
+}
@@ -0,0 +1,44 @@
+package fibonacciheap_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/fibonacciheap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollect(t *testing.T) {
+	h := fibonacciheap.Collect(slices.Values([]int{4, 9, 2, 7}), comparator.Reverse(cmp.Compare[int]))
+	assert.Equal(t, 4, h.Len())
+	v, _ := h.Top()
+	assert.Equal(t, 2, v)
+}
+
+func TestStaleElementPanics(t *testing.T) {
+	h := fibonacciheap.New(cmp.Compare[int])
+	e := h.Insert(1)
+	h.Pop()
+	assert.PanicsWithValue(t, "fibonacciheap.Update: element not in heap", func() {
+		h.Update(e, 2)
+	})
+	assert.PanicsWithValue(t, "fibonacciheap.Remove: element not in heap", func() {
+		h.Remove(e)
+	})
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestPriorityQueue(t, fibonacciheap.New[int])
+	containertest.TestAddressablePriorityQueue[*fibonacciheap.Element[int]](t, fibonacciheap.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestPriorityQueueModel(t, fibonacciheap.New[int])
+}
+
+func FuzzFibonacciHeap(f *testing.F) {
+	containertest.FuzzPriorityQueue(f, fibonacciheap.New[int])
+}
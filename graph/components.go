@@ -0,0 +1,219 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/linhns/gocontainers/hashmap"
+	"github.com/linhns/gocontainers/hashset"
+	"github.com/linhns/gocontainers/stack"
+)
+
+// ConnectedComponents returns the connected components of the graph, each
+// as the list of its vertices, in O(V + E α(V)) time. The edges of a
+// directed graph are followed both ways, which gives its weakly connected
+// components. The components and their vertices are in unspecified order.
+func (g *Graph[K, W]) ConnectedComponents() [][]K {
+	ds := newDisjointSet[K]()
+	for e := range g.Edges() {
+		ds.union(e.From, e.To)
+	}
+	index := hashmap.New[K, int]()
+	var components [][]K
+	for v := range g.Vertices() {
+		root := ds.find(v)
+		i, ok := index.Get(root)
+		if !ok {
+			i = len(components)
+			index.Insert(root, i)
+			components = append(components, nil)
+		}
+		components[i] = append(components[i], v)
+	}
+	return components
+}
+
+// StronglyConnectedComponents returns the strongly connected components
+// of the graph, each as the list of its vertices, with Tarjan's algorithm
+// in O(V + E) time. Two vertices are in the same component if each is
+// reachable from the other.
+//
+// The components are in reverse topological order: no edge leads from a
+// component to an earlier one. The vertices of each component are in
+// unspecified order.
+func (g *Graph[K, W]) StronglyConnectedComponents() [][]K {
+	t := tarjan[K, W]{
+		g:       g,
+		index:   hashmap.New[K, int](),
+		onStack: hashset.New[K](),
+		stack:   stack.New[K](),
+		calls:   stack.New[*tarjanCall[K]](),
+	}
+	for v := range g.Vertices() {
+		if !t.index.Contains(v) {
+			t.visit(v)
+		}
+	}
+	return t.components
+}
+
+// tarjan holds the state of Tarjan's strongly connected components
+// algorithm.
+type tarjan[K comparable, W Weight] struct {
+	g *Graph[K, W]
+	// index numbers the vertices in the order they are visited.
+	index   *hashmap.HashMap[K, int]
+	onStack *hashset.HashSet[K]
+	stack   *stack.Stack[K]
+	// calls holds the vertices being visited, innermost on top, in place
+	// of the call stack of a recursive depth-first search, so that deep
+	// graphs cannot overflow the goroutine stack.
+	calls      *stack.Stack[*tarjanCall[K]]
+	components [][]K
+}
+
+// tarjanCall is the visit of a vertex by [tarjan.visit].
+type tarjanCall[K comparable] struct {
+	vertex K
+	index  int
+	// lowlink is the lowest index reachable from vertex through the
+	// vertices on the stack found so far.
+	lowlink int
+	// next holds the neighbors of vertex not explored yet.
+	next []K
+}
+
+// visit runs a depth-first search from v, which has not been visited.
+func (t *tarjan[K, W]) visit(v K) {
+	t.enter(v)
+	for !t.calls.Empty() {
+		c, _ := t.calls.Top()
+		if len(c.next) > 0 {
+			u := c.next[0]
+			c.next = c.next[1:]
+			if !t.index.Contains(u) {
+				t.enter(u)
+			} else if t.onStack.Contains(u) {
+				j, _ := t.index.Get(u)
+				c.lowlink = min(c.lowlink, j)
+			}
+			continue
+		}
+
+		t.calls.Pop()
+		if parent, ok := t.calls.Top(); ok {
+			parent.lowlink = min(parent.lowlink, c.lowlink)
+		}
+		// c.vertex is the first visited vertex of its component, whose
+		// vertices are on the stack above it.
+		if c.lowlink == c.index {
+			var component []K
+			for {
+				u, _ := t.stack.Pop()
+				t.onStack.Remove(u)
+				component = append(component, u)
+				if u == c.vertex {
+					break
+				}
+			}
+			t.components = append(t.components, component)
+		}
+	}
+}
+
+// enter starts the visit of v.
+func (t *tarjan[K, W]) enter(v K) {
+	i := t.index.Len()
+	t.index.Insert(v, i)
+	t.stack.Push(v)
+	t.onStack.Add(v)
+	t.calls.Push(&tarjanCall[K]{
+		vertex:  v,
+		index:   i,
+		lowlink: i,
+		next:    slices.Collect(t.g.mustNeighbors(v).Keys()),
+	})
+}
+
+// MinimumSpanningTree returns the edges of a minimum spanning forest of an
+// undirected graph, which connects the vertices of each connected
+// component with the lowest total weight, and that weight. It uses
+// Kruskal's algorithm and takes O(E log E) time.
+//
+// If the graph is directed, MinimumSpanningTree panics.
+func (g *Graph[K, W]) MinimumSpanningTree() ([]Edge[K, W], W) {
+	if g.directed {
+		panic("graph.MinimumSpanningTree: directed graph")
+	}
+	edges := slices.SortedFunc(g.Edges(), func(a, b Edge[K, W]) int {
+		return cmp.Compare(a.Weight, b.Weight)
+	})
+
+	ds := newDisjointSet[K]()
+	var (
+		tree  []Edge[K, W]
+		total W
+	)
+	for _, e := range edges {
+		if ds.union(e.From, e.To) {
+			tree = append(tree, e)
+			total += e.Weight
+		}
+	}
+	return tree, total
+}
+
+// disjointSet is a union-find structure over the vertices of a graph.
+// Vertices not yet seen are in sets of their own.
+type disjointSet[K comparable] struct {
+	parent *hashmap.HashMap[K, K]
+	size   *hashmap.HashMap[K, int]
+}
+
+func newDisjointSet[K comparable]() *disjointSet[K] {
+	return &disjointSet[K]{
+		parent: hashmap.New[K, K](),
+		size:   hashmap.New[K, int](),
+	}
+}
+
+// find returns the representative of the set containing v.
+func (ds *disjointSet[K]) find(v K) K {
+	for {
+		p, ok := ds.parent.Get(v)
+		if !ok {
+			return v
+		}
+		gp, ok := ds.parent.Get(p)
+		if !ok {
+			return p
+		}
+		// Halve the path to the representative as we walk it, by pointing
+		// every other vertex on it at its grandparent.
+		ds.parent.Insert(v, gp)
+		v = gp
+	}
+}
+
+// union merges the sets containing a and b and reports whether they were
+// different.
+func (ds *disjointSet[K]) union(a, b K) bool {
+	a, b = ds.find(a), ds.find(b)
+	if a == b {
+		return false
+	}
+	sa, sb := ds.setSize(a), ds.setSize(b)
+	if sa < sb {
+		a, b = b, a
+	}
+	ds.parent.Insert(b, a)
+	ds.size.Insert(a, sa+sb)
+	return true
+}
+
+func (ds *disjointSet[K]) setSize(root K) int {
+	if s, ok := ds.size.Get(root); ok {
+		return s
+	}
+	return 1
+}
@@ -0,0 +1,204 @@
+// Package graph provides a generic weighted graph, directed or undirected,
+// with traversals, shortest paths, components and spanning trees.
+package graph
+
+import (
+	"iter"
+
+	"github.com/linhns/gocontainers/hashmap"
+	"github.com/linhns/gocontainers/hashset"
+)
+
+// Weight is a constraint that permits any integer or floating-point type.
+type Weight interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Edge is an edge from From to To with a weight. The edges of an
+// undirected graph connect From and To both ways.
+type Edge[K comparable, W Weight] struct {
+	From, To K
+	Weight   W
+}
+
+// Graph is a weighted graph with vertices of type K and edge weights of
+// type W, stored as adjacency lists. There is at most one edge from a
+// vertex to another.
+type Graph[K comparable, W Weight] struct {
+	// adj maps each vertex to its neighbors and the weights of the edges
+	// to them. An undirected edge is stored in the adjacency lists of both
+	// its ends.
+	adj      *hashmap.HashMap[K, *hashmap.HashMap[K, W]]
+	edges    int
+	directed bool
+}
+
+// NewDirected creates a new, empty directed [Graph].
+func NewDirected[K comparable, W Weight]() *Graph[K, W] {
+	return &Graph[K, W]{adj: hashmap.New[K, *hashmap.HashMap[K, W]](), directed: true}
+}
+
+// NewUndirected creates a new, empty undirected [Graph].
+func NewUndirected[K comparable, W Weight]() *Graph[K, W] {
+	return &Graph[K, W]{adj: hashmap.New[K, *hashmap.HashMap[K, W]]()}
+}
+
+// Directed reports whether the graph is directed.
+func (g *Graph[K, W]) Directed() bool {
+	return g.directed
+}
+
+// NumVertices returns the number of vertices in the graph.
+func (g *Graph[K, W]) NumVertices() int {
+	return g.adj.Len()
+}
+
+// NumEdges returns the number of edges in the graph.
+func (g *Graph[K, W]) NumEdges() int {
+	return g.edges
+}
+
+// AddVertex adds the vertex v to the graph, if it is not already in it.
+func (g *Graph[K, W]) AddVertex(v K) {
+	g.neighbors(v)
+}
+
+// HasVertex reports whether the graph contains the vertex v.
+func (g *Graph[K, W]) HasVertex(v K) bool {
+	return g.adj.Contains(v)
+}
+
+// RemoveVertex removes the vertex v and its edges from the graph.
+//
+// In a directed graph, finding the edges to v takes O(V) time.
+func (g *Graph[K, W]) RemoveVertex(v K) {
+	out, ok := g.adj.Get(v)
+	if !ok {
+		return
+	}
+	if g.directed {
+		for u, neighbors := range g.adj.All() {
+			if u != v && neighbors.Contains(v) {
+				neighbors.Remove(v)
+				g.edges--
+			}
+		}
+	} else {
+		for u := range out.Keys() {
+			if u != v {
+				g.mustNeighbors(u).Remove(v)
+			}
+		}
+	}
+	g.edges -= out.Len()
+	g.adj.Remove(v)
+}
+
+// AddEdge adds an edge from one vertex to another with the specified
+// weight, adding the vertices if needed. If there is already such an
+// edge, its weight is replaced.
+func (g *Graph[K, W]) AddEdge(from, to K, weight W) {
+	out := g.neighbors(from)
+	if !out.Contains(to) {
+		g.edges++
+	}
+	out.Insert(to, weight)
+	in := g.neighbors(to)
+	if !g.directed {
+		in.Insert(from, weight)
+	}
+}
+
+// HasEdge reports whether the graph contains an edge from one vertex to
+// another.
+func (g *Graph[K, W]) HasEdge(from, to K) bool {
+	_, ok := g.Weight(from, to)
+	return ok
+}
+
+// Weight returns the weight of the edge from one vertex to another, if
+// there is one.
+func (g *Graph[K, W]) Weight(from, to K) (W, bool) {
+	out, ok := g.adj.Get(from)
+	if !ok {
+		var zero W
+		return zero, false
+	}
+	return out.Get(to)
+}
+
+// RemoveEdge removes the edge from one vertex to another, if there is
+// one. The vertices are kept.
+func (g *Graph[K, W]) RemoveEdge(from, to K) {
+	out, ok := g.adj.Get(from)
+	if !ok || !out.Contains(to) {
+		return
+	}
+	out.Remove(to)
+	if !g.directed {
+		g.mustNeighbors(to).Remove(from)
+	}
+	g.edges--
+}
+
+// Vertices returns an iterator over the vertices of the graph in
+// unspecified order.
+func (g *Graph[K, W]) Vertices() iter.Seq[K] {
+	return g.adj.Keys()
+}
+
+// Neighbors returns an iterator over the vertices that the edges from v
+// lead to and the weights of the edges, in unspecified order.
+func (g *Graph[K, W]) Neighbors(v K) iter.Seq2[K, W] {
+	return func(yield func(K, W) bool) {
+		out, ok := g.adj.Get(v)
+		if !ok {
+			return
+		}
+		for u, w := range out.All() {
+			if !yield(u, w) {
+				return
+			}
+		}
+	}
+}
+
+// Edges returns an iterator over the edges of the graph in unspecified
+// order. Each edge of an undirected graph is yielded once, in either
+// direction.
+func (g *Graph[K, W]) Edges() iter.Seq[Edge[K, W]] {
+	return func(yield func(Edge[K, W]) bool) {
+		done := hashset.New[K]()
+		for from, out := range g.adj.All() {
+			for to, w := range out.All() {
+				if !g.directed && done.Contains(to) {
+					continue
+				}
+				if !yield(Edge[K, W]{from, to, w}) {
+					return
+				}
+			}
+			done.Add(from)
+		}
+	}
+}
+
+// neighbors returns the adjacency list of v, adding v to the graph if
+// needed.
+func (g *Graph[K, W]) neighbors(v K) *hashmap.HashMap[K, W] {
+	out, ok := g.adj.Get(v)
+	if !ok {
+		out = hashmap.New[K, W]()
+		g.adj.Insert(v, out)
+	}
+	return out
+}
+
+// mustNeighbors returns the adjacency list of v, which must be in the
+// graph.
+func (g *Graph[K, W]) mustNeighbors(v K) *hashmap.HashMap[K, W] {
+	out, _ := g.adj.Get(v)
+	return out
+}
@@ -0,0 +1,460 @@
+package graph_test
+
+import (
+	"maps"
+	"math/rand/v2"
+	"runtime/debug"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/graph"
+)
+
+type edge = graph.Edge[int, int]
+
+// randomGraph returns a graph with n vertices and about m random edges
+// with weights in [0, 10).
+func randomGraph(r *rand.Rand, directed bool, n, m int) *graph.Graph[int, int] {
+	g := graph.NewUndirected[int, int]()
+	if directed {
+		g = graph.NewDirected[int, int]()
+	}
+	for v := range n {
+		g.AddVertex(v)
+	}
+	for range m {
+		g.AddEdge(r.IntN(n), r.IntN(n), r.IntN(10))
+	}
+	return g
+}
+
+// reachable returns the transitive closure of g, with reach[u][v]
+// reporting whether v is reachable from u.
+func reachable(g *graph.Graph[int, int]) [][]bool {
+	n := g.NumVertices()
+	reach := make([][]bool, n)
+	for u := range reach {
+		reach[u] = make([]bool, n)
+		reach[u][u] = true
+	}
+	for e := range g.Edges() {
+		reach[e.From][e.To] = true
+		if !g.Directed() {
+			reach[e.To][e.From] = true
+		}
+	}
+	for k := range n {
+		for i := range n {
+			for j := range n {
+				reach[i][j] = reach[i][j] || reach[i][k] && reach[k][j]
+			}
+		}
+	}
+	return reach
+}
+
+func sortedComponents(components [][]int) [][]int {
+	for _, c := range components {
+		slices.Sort(c)
+	}
+	slices.SortFunc(components, func(a, b []int) int { return a[0] - b[0] })
+	return components
+}
+
+func TestGraph(t *testing.T) {
+	g := graph.NewDirected[string, int]()
+	assert.True(t, g.Directed())
+	g.AddEdge("a", "b", 1)
+	assert.True(t, g.HasVertex("b"))
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("c", "a", 3)
+	g.AddEdge("a", "a", 4)
+	g.AddEdge("a", "b", 5)
+	g.AddVertex("d")
+	assert.Equal(t, 4, g.NumVertices())
+	assert.Equal(t, 4, g.NumEdges())
+	assert.True(t, g.HasEdge("a", "b"))
+	assert.False(t, g.HasEdge("b", "a"))
+	w, ok := g.Weight("a", "b")
+	assert.True(t, ok)
+	assert.Equal(t, 5, w)
+	_, ok = g.Weight("x", "a")
+	assert.False(t, ok)
+	assert.Equal(t, map[string]int{"a": 4, "b": 5}, maps.Collect(g.Neighbors("a")))
+
+	g.RemoveVertex("a")
+	assert.False(t, g.HasVertex("a"))
+	assert.Equal(t, 3, g.NumVertices())
+	assert.Equal(t, 1, g.NumEdges())
+	assert.Equal(t, []graph.Edge[string, int]{{"b", "c", 2}}, slices.Collect(g.Edges()))
+
+	g.RemoveEdge("b", "c")
+	g.RemoveEdge("b", "c")
+	assert.Equal(t, 0, g.NumEdges())
+	assert.True(t, g.HasVertex("c"))
+}
+
+func TestUndirectedGraph(t *testing.T) {
+	g := graph.NewUndirected[int, int]()
+	assert.False(t, g.Directed())
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 1, 7)
+	g.AddEdge(2, 3, 2)
+	g.AddEdge(3, 3, 3)
+	assert.Equal(t, 3, g.NumEdges())
+	assert.True(t, g.HasEdge(2, 1))
+	w, _ := g.Weight(1, 2)
+	assert.Equal(t, 7, w)
+
+	edges := slices.Collect(g.Edges())
+	assert.Len(t, edges, 3)
+	assert.Contains(t, edges, edge{3, 3, 3})
+
+	g.RemoveEdge(3, 2)
+	assert.False(t, g.HasEdge(2, 3))
+	assert.Equal(t, 2, g.NumEdges())
+
+	g.RemoveVertex(2)
+	assert.Equal(t, 1, g.NumEdges())
+	assert.False(t, g.HasEdge(1, 2))
+	assert.Empty(t, maps.Collect(g.Neighbors(1)))
+}
+
+func TestTraversal(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range 50 {
+		g := randomGraph(r, i%2 == 0, 20, 30)
+		reach := reachable(g)
+
+		// BFS visits vertices in order of their distance from the start,
+		// which is at most one more than that of a vertex visited before.
+		dist := map[int]int{0: 0}
+		var order []int
+		for v := range g.BFS(0) {
+			if len(order) > 0 {
+				assert.GreaterOrEqual(t, dist[v], dist[order[len(order)-1]])
+			}
+			order = append(order, v)
+			for u := range g.Neighbors(v) {
+				if _, ok := dist[u]; !ok {
+					dist[u] = dist[v] + 1
+				}
+			}
+		}
+		var want []int
+		for v, ok := range reach[0] {
+			if ok {
+				want = append(want, v)
+			}
+		}
+		assert.ElementsMatch(t, want, order)
+
+		dfs := slices.Collect(g.DFS(0))
+		assert.Equal(t, 0, dfs[0])
+		assert.ElementsMatch(t, want, dfs)
+	}
+
+	g := graph.NewDirected[int, int]()
+	assert.Empty(t, slices.Collect(g.BFS(0)))
+	assert.Empty(t, slices.Collect(g.DFS(0)))
+	g.AddEdge(0, 1, 1)
+	for range g.BFS(0) {
+		break
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 50 {
+		// Edges from lower to higher vertices make a DAG.
+		g := graph.NewDirected[int, int]()
+		perm := r.Perm(20)
+		for _, v := range perm {
+			g.AddVertex(v)
+		}
+		for range 40 {
+			u, v := r.IntN(20), r.IntN(20)
+			if u != v {
+				g.AddEdge(perm[min(u, v)], perm[max(u, v)], 1)
+			}
+		}
+		order, err := g.TopologicalSort()
+		assert.NoError(t, err)
+		assert.Len(t, order, 20)
+		pos := make(map[int]int)
+		for i, v := range order {
+			pos[v] = i
+		}
+		for e := range g.Edges() {
+			assert.Less(t, pos[e.From], pos[e.To])
+		}
+
+		g.AddEdge(perm[19], perm[0], 1)
+		g.AddEdge(perm[0], perm[19], 1)
+		_, err = g.TopologicalSort()
+		assert.ErrorIs(t, err, graph.ErrCycle)
+	}
+
+	assert.PanicsWithValue(t, "graph.TopologicalSort: undirected graph", func() {
+		graph.NewUndirected[int, int]().TopologicalSort()
+	})
+}
+
+func TestShortestPaths(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for i := range 50 {
+		g := randomGraph(r, i%2 == 0, 15, 40)
+
+		// Bellman-Ford computes the distances from 0 to compare against.
+		dist := map[int]int{0: 0}
+		for range g.NumVertices() {
+			for e := range g.Edges() {
+				for _, e := range []edge{e, {e.To, e.From, e.Weight}} {
+					if d, ok := dist[e.From]; ok {
+						if old, ok := dist[e.To]; !ok || d+e.Weight < old {
+							dist[e.To] = d + e.Weight
+						}
+					}
+					if g.Directed() {
+						break
+					}
+				}
+			}
+		}
+
+		p := g.Dijkstra(0)
+		assert.Equal(t, 0, p.Source())
+		for v := range g.Vertices() {
+			want, reachable := dist[v]
+			got, ok := p.DistTo(v)
+			assert.Equal(t, reachable, ok)
+			assert.Equal(t, want, got)
+
+			path, ok := p.PathTo(v)
+			assert.Equal(t, reachable, ok)
+			if !ok {
+				_, _, ok := g.AStar(0, v, func(int) int { return 0 })
+				assert.False(t, ok)
+				continue
+			}
+			assert.Equal(t, []int{0, v}, []int{path[0], path[len(path)-1]})
+			assert.Equal(t, want, pathLength(t, g, path))
+
+			path, length, ok := g.AStar(0, v, func(int) int { return 0 })
+			assert.True(t, ok)
+			assert.Equal(t, want, length)
+			assert.Equal(t, want, pathLength(t, g, path))
+		}
+	}
+}
+
+func pathLength(t *testing.T, g *graph.Graph[int, int], path []int) int {
+	total := 0
+	for i := 1; i < len(path); i++ {
+		w, ok := g.Weight(path[i-1], path[i])
+		assert.True(t, ok)
+		total += w
+	}
+	return total
+}
+
+func TestAStarGrid(t *testing.T) {
+	type cell struct{ x, y int }
+	const size = 20
+
+	// A grid with a wall across the middle, open at the right end.
+	g := graph.NewUndirected[cell, float64]()
+	for x := range size {
+		for y := range size {
+			if y == size/2 && x < size-1 {
+				continue
+			}
+			for _, next := range []cell{{x + 1, y}, {x, y + 1}} {
+				if next.x < size && next.y < size && (next.y != size/2 || next.x == size-1) {
+					g.AddEdge(cell{x, y}, next, 1)
+				}
+			}
+		}
+	}
+
+	target := cell{0, size - 1}
+	manhattan := func(c cell) float64 {
+		return float64(max(c.x-target.x, target.x-c.x) + max(c.y-target.y, target.y-c.y))
+	}
+	path, length, ok := g.AStar(cell{0, 0}, target, manhattan)
+	assert.True(t, ok)
+	assert.Equal(t, float64(2*(size-1)+size-1), length)
+	assert.Len(t, path, int(length)+1)
+
+	want, _ := g.Dijkstra(cell{0, 0}).DistTo(target)
+	assert.Equal(t, want, length)
+
+	_, _, ok = g.AStar(cell{0, 0}, cell{-1, -1}, manhattan)
+	assert.False(t, ok)
+}
+
+func TestNegativeWeightPanics(t *testing.T) {
+	g := graph.NewDirected[int, int]()
+	g.AddEdge(0, 1, -1)
+	assert.PanicsWithValue(t, "graph.Dijkstra: negative edge weight", func() { g.Dijkstra(0) })
+	assert.PanicsWithValue(t, "graph.AStar: negative edge weight", func() {
+		g.AStar(0, 1, func(int) int { return 0 })
+	})
+}
+
+func TestConnectedComponents(t *testing.T) {
+	g := graph.NewUndirected[int, int]()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddVertex(5)
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4}, {5}}, sortedComponents(g.ConnectedComponents()))
+
+	r := rand.New(rand.NewPCG(7, 8))
+	for i := range 50 {
+		g := randomGraph(r, i%2 == 0, 20, 15)
+		// Two vertices are weakly connected if either reaches the other
+		// when edges are followed both ways.
+		undirected := graph.NewUndirected[int, int]()
+		for v := range g.Vertices() {
+			undirected.AddVertex(v)
+		}
+		for e := range g.Edges() {
+			undirected.AddEdge(e.From, e.To, e.Weight)
+		}
+		reach := reachable(undirected)
+		for _, c := range g.ConnectedComponents() {
+			for _, u := range c {
+				for v := range g.Vertices() {
+					assert.Equal(t, reach[u][v], slices.Contains(c, v))
+				}
+			}
+		}
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := graph.NewDirected[int, int]()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 3, 1)
+	g.AddVertex(5)
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4}, {5}}, sortedComponents(g.StronglyConnectedComponents()))
+
+	r := rand.New(rand.NewPCG(9, 10))
+	for range 50 {
+		g := randomGraph(r, true, 20, 30)
+		reach := reachable(g)
+		components := g.StronglyConnectedComponents()
+
+		component := make(map[int]int)
+		for i, c := range components {
+			for _, v := range c {
+				component[v] = i
+			}
+		}
+		assert.Len(t, component, g.NumVertices())
+		for u := range g.Vertices() {
+			for v := range g.Vertices() {
+				assert.Equal(t, reach[u][v] && reach[v][u], component[u] == component[v])
+			}
+		}
+		// Components come in reverse topological order.
+		for e := range g.Edges() {
+			assert.GreaterOrEqual(t, component[e.From], component[e.To])
+		}
+	}
+}
+
+func TestStronglyConnectedComponentsDeep(t *testing.T) {
+	// A path too long for a recursive search to fit in the stack, closed
+	// into a cycle.
+	defer debug.SetMaxStack(debug.SetMaxStack(1 << 20))
+	const n = 100000
+	g := graph.NewDirected[int, int]()
+	for i := range n - 1 {
+		g.AddEdge(i, i+1, 1)
+	}
+	g.AddEdge(n-1, 0, 1)
+	components := g.StronglyConnectedComponents()
+	assert.Len(t, components, 1)
+	assert.Len(t, components[0], n)
+}
+
+func TestMinimumSpanningTree(t *testing.T) {
+	g := graph.NewUndirected[string, int]()
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("b", "d", 5)
+	g.AddEdge("c", "d", 8)
+	g.AddEdge("e", "f", 3)
+	tree, total := g.MinimumSpanningTree()
+	assert.Equal(t, 11, total)
+	assert.Len(t, tree, 4)
+
+	r := rand.New(rand.NewPCG(11, 12))
+	for range 50 {
+		g := randomGraph(r, false, 12, 30)
+		tree, total := g.MinimumSpanningTree()
+		assert.Equal(t, g.NumVertices()-len(g.ConnectedComponents()), len(tree))
+		assert.Equal(t, primTotal(g), total)
+
+		sum := 0
+		forest := graph.NewUndirected[int, int]()
+		for _, e := range tree {
+			assert.True(t, g.HasEdge(e.From, e.To))
+			forest.AddEdge(e.From, e.To, e.Weight)
+			sum += e.Weight
+		}
+		assert.Equal(t, total, sum)
+		assert.Equal(t, len(tree), forest.NumEdges())
+	}
+
+	assert.PanicsWithValue(t, "graph.MinimumSpanningTree: directed graph", func() {
+		graph.NewDirected[int, int]().MinimumSpanningTree()
+	})
+}
+
+// primTotal returns the weight of a minimum spanning forest of g, growing
+// a tree from every vertex not yet in one with Prim's algorithm.
+func primTotal(g *graph.Graph[int, int]) int {
+	inTree := make(map[int]bool)
+	total := 0
+	for root := range g.Vertices() {
+		if inTree[root] {
+			continue
+		}
+		inTree[root] = true
+		for {
+			best, bestWeight := -1, 0
+			for e := range g.Edges() {
+				for _, e := range []edge{e, {e.To, e.From, e.Weight}} {
+					if inTree[e.From] && !inTree[e.To] && (best < 0 || e.Weight < bestWeight) {
+						best, bestWeight = e.To, e.Weight
+					}
+				}
+			}
+			if best < 0 {
+				break
+			}
+			inTree[best] = true
+			total += bestWeight
+		}
+	}
+	return total
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 2))
+	g := randomGraph(r, true, 1000, 10000)
+	b.ResetTimer()
+	for range b.N {
+		g.Dijkstra(0)
+	}
+}
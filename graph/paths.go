@@ -0,0 +1,155 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/linhns/gocontainers/hashmap"
+	"github.com/linhns/gocontainers/priorityqueue"
+)
+
+// ShortestPaths holds the shortest paths from a source vertex to every
+// vertex reachable from it, as computed by [Graph.Dijkstra].
+type ShortestPaths[K comparable, W Weight] struct {
+	source K
+	dist   *hashmap.HashMap[K, W]
+	// prev maps each reachable vertex other than the source to the vertex
+	// before it on its shortest path.
+	prev *hashmap.HashMap[K, K]
+}
+
+// Source returns the vertex the paths start from.
+func (p *ShortestPaths[K, W]) Source() K {
+	return p.source
+}
+
+// DistTo returns the length of the shortest path to v, if v is reachable
+// from the source.
+func (p *ShortestPaths[K, W]) DistTo(v K) (W, bool) {
+	return p.dist.Get(v)
+}
+
+// PathTo returns the vertices on the shortest path to v, from the source
+// to v, if v is reachable from the source.
+func (p *ShortestPaths[K, W]) PathTo(v K) ([]K, bool) {
+	if !p.dist.Contains(v) {
+		return nil, false
+	}
+	return walkBack(p.prev, v), true
+}
+
+// visit is a vertex in the queue of a shortest path search, with its
+// distance from the source and its priority.
+type visit[K comparable, W Weight] struct {
+	vertex   K
+	dist     W
+	priority W
+}
+
+// newVisitQueue creates a new priority queue that pops the visit with the
+// lowest priority first.
+func newVisitQueue[K comparable, W Weight]() *priorityqueue.PriorityQueue[visit[K, W]] {
+	return priorityqueue.New(func(a, b visit[K, W]) int {
+		return cmp.Compare(b.priority, a.priority)
+	})
+}
+
+// Dijkstra computes the shortest paths from source to every vertex
+// reachable from it with Dijkstra's algorithm, in O((V + E) log V) time.
+//
+// If Dijkstra reaches an edge with a negative weight, it panics.
+func (g *Graph[K, W]) Dijkstra(source K) *ShortestPaths[K, W] {
+	p := &ShortestPaths[K, W]{
+		source: source,
+		dist:   hashmap.New[K, W](),
+		prev:   hashmap.New[K, K](),
+	}
+	if !g.HasVertex(source) {
+		return p
+	}
+
+	var zero W
+	p.dist.Insert(source, zero)
+	q := newVisitQueue[K, W]()
+	q.Push(visit[K, W]{source, zero, zero})
+	for !q.Empty() {
+		cur, _ := q.Pop()
+		if d, _ := p.dist.Get(cur.vertex); cur.dist > d {
+			// A shorter path to the vertex was found after this visit was
+			// queued.
+			continue
+		}
+		for u, w := range g.mustNeighbors(cur.vertex).All() {
+			if w < zero {
+				panic("graph.Dijkstra: negative edge weight")
+			}
+			alt := cur.dist + w
+			if d, ok := p.dist.Get(u); !ok || alt < d {
+				p.dist.Insert(u, alt)
+				p.prev.Insert(u, cur.vertex)
+				q.Push(visit[K, W]{u, alt, alt})
+			}
+		}
+	}
+	return p
+}
+
+// AStar finds a shortest path from source to target with the A* search
+// algorithm and returns its vertices, from source to target, and its
+// length. If target is not reachable from source, it returns false.
+//
+// heuristic estimates the length of the shortest path from a vertex to
+// target. It must never overestimate it for the path found to be
+// shortest, and the closer it is, the fewer vertices are explored. A
+// heuristic that always returns zero makes AStar equivalent to
+// [Graph.Dijkstra] stopped at target.
+//
+// If AStar reaches an edge with a negative weight, it panics.
+func (g *Graph[K, W]) AStar(source, target K, heuristic func(K) W) ([]K, W, bool) {
+	var zero W
+	if !g.HasVertex(source) || !g.HasVertex(target) {
+		return nil, zero, false
+	}
+
+	dist := hashmap.New[K, W]()
+	prev := hashmap.New[K, K]()
+	dist.Insert(source, zero)
+	q := newVisitQueue[K, W]()
+	q.Push(visit[K, W]{source, zero, heuristic(source)})
+	for !q.Empty() {
+		cur, _ := q.Pop()
+		if d, _ := dist.Get(cur.vertex); cur.dist > d {
+			continue
+		}
+		if cur.vertex == target {
+			return walkBack(prev, target), cur.dist, true
+		}
+		for u, w := range g.mustNeighbors(cur.vertex).All() {
+			if w < zero {
+				panic("graph.AStar: negative edge weight")
+			}
+			alt := cur.dist + w
+			if d, ok := dist.Get(u); !ok || alt < d {
+				dist.Insert(u, alt)
+				prev.Insert(u, cur.vertex)
+				q.Push(visit[K, W]{u, alt, alt + heuristic(u)})
+			}
+		}
+	}
+	return nil, zero, false
+}
+
+// walkBack returns the path to v by following prev back from v.
+func walkBack[K comparable](prev *hashmap.HashMap[K, K], v K) []K {
+	path := []K{v}
+	for {
+		u, ok := prev.Get(v)
+		if !ok {
+			break
+		}
+		path = append(path, u)
+		v = u
+	}
+	slices.Reverse(path)
+	return path
+}
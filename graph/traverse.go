@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/linhns/gocontainers/hashmap"
+	"github.com/linhns/gocontainers/hashset"
+	"github.com/linhns/gocontainers/queue"
+	"github.com/linhns/gocontainers/stack"
+)
+
+// ErrCycle is returned by [Graph.TopologicalSort] for a graph with a
+// cycle.
+var ErrCycle = errors.New("graph: cycle")
+
+// BFS returns an iterator over the vertices reachable from start in
+// breadth-first order, starting with start itself. Vertices at the same
+// distance from start are yielded in unspecified order.
+func (g *Graph[K, W]) BFS(start K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		if !g.HasVertex(start) {
+			return
+		}
+		visited := hashset.New[K]()
+		visited.Add(start)
+		q := queue.New[K]()
+		q.Push(start)
+		for !q.Empty() {
+			v, _ := q.Pop()
+			if !yield(v) {
+				return
+			}
+			for u := range g.mustNeighbors(v).Keys() {
+				if !visited.Contains(u) {
+					visited.Add(u)
+					q.Push(u)
+				}
+			}
+		}
+	}
+}
+
+// DFS returns an iterator over the vertices reachable from start in
+// depth-first preorder, starting with start itself. The neighbors of a
+// vertex are explored in unspecified order.
+func (g *Graph[K, W]) DFS(start K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		if !g.HasVertex(start) {
+			return
+		}
+		visited := hashset.New[K]()
+		s := stack.New[K]()
+		s.Push(start)
+		for !s.Empty() {
+			v, _ := s.Pop()
+			if visited.Contains(v) {
+				continue
+			}
+			visited.Add(v)
+			if !yield(v) {
+				return
+			}
+			for u := range g.mustNeighbors(v).Keys() {
+				if !visited.Contains(u) {
+					s.Push(u)
+				}
+			}
+		}
+	}
+}
+
+// TopologicalSort returns the vertices of a directed graph ordered so
+// that every edge leads from a vertex to a later one, in O(V + E) time.
+// If the graph has a cycle, there is no such order and TopologicalSort
+// returns [ErrCycle].
+//
+// If the graph is undirected, TopologicalSort panics.
+func (g *Graph[K, W]) TopologicalSort() ([]K, error) {
+	if !g.directed {
+		panic("graph.TopologicalSort: undirected graph")
+	}
+	inDegree := hashmap.New[K, int]()
+	for v, out := range g.adj.All() {
+		if !inDegree.Contains(v) {
+			inDegree.Insert(v, 0)
+		}
+		for u := range out.Keys() {
+			d, _ := inDegree.Get(u)
+			inDegree.Insert(u, d+1)
+		}
+	}
+
+	// Repeatedly remove the vertices with no edges to them left.
+	q := queue.New[K]()
+	for v, d := range inDegree.All() {
+		if d == 0 {
+			q.Push(v)
+		}
+	}
+	order := make([]K, 0, g.NumVertices())
+	for !q.Empty() {
+		v, _ := q.Pop()
+		order = append(order, v)
+		for u := range g.mustNeighbors(v).Keys() {
+			d, _ := inDegree.Get(u)
+			inDegree.Insert(u, d-1)
+			if d == 1 {
+				q.Push(u)
+			}
+		}
+	}
+	if len(order) < g.NumVertices() {
+		return nil, ErrCycle
+	}
+	return order, nil
+}
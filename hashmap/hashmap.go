@@ -4,6 +4,8 @@ package hashmap
 import (
 	"iter"
 	"maps"
+
+	"github.com/linhns/gocontainers/container"
 )
 
 // HashMap is a generic hash table (map).
@@ -11,6 +13,8 @@ type HashMap[K comparable, V any] struct {
 	data map[K]V
 }
 
+var _ container.Map[int, int] = (*HashMap[int, int])(nil)
+
 // New creates and initialize a new [HashMap].
 func New[K comparable, V any]() *HashMap[K, V] {
 	return &HashMap[K, V]{
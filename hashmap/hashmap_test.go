@@ -5,6 +5,7 @@ import (
 	"slices"
 	"testing"
 
+	"github.com/linhns/gocontainers/containertest"
 	"github.com/linhns/gocontainers/hashmap"
 	"github.com/stretchr/testify/assert"
 )
@@ -62,3 +63,15 @@ func TestMapIterator(t *testing.T) {
 	got := maps.Collect(hashmap.Collect(m.All()).All())
 	assert.Equal(t, want, got)
 }
+
+func TestConformance(t *testing.T) {
+	containertest.TestMap(t, hashmap.New[int, string])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestMapModel(t, hashmap.New[int, string])
+}
+
+func FuzzHashMap(f *testing.F) {
+	containertest.FuzzMap(f, hashmap.New[int, string])
+}
@@ -1,13 +1,22 @@
 // Package hashset implements a set data structure based on hash table.
 package hashset
 
-import "iter"
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/linhns/gocontainers/container"
+)
 
 // HashSet holds a set of unique elements
 type HashSet[K comparable] struct {
 	data map[K]struct{}
 }
 
+var _ container.Set[int] = (*HashSet[int])(nil)
+
 // Equal reports whether two sets contain the same elements
 func Equal[K comparable](s1, s2 *HashSet[K]) bool {
 	if len(s1.data) != len(s2.data) {
@@ -82,30 +91,51 @@ func Collect[K comparable](seq iter.Seq[K]) *HashSet[K] {
 	return s
 }
 
-// Union returns a new set that contains all elements from two sets
-func Union[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
-	result := New[K]()
-	for v := range s1.data {
-		result.data[v] = struct{}{}
+// Union returns a new set that contains all elements from the given sets
+func Union[K comparable](sets ...*HashSet[K]) *HashSet[K] {
+	n := 0
+	for _, s := range sets {
+		n = max(n, len(s.data))
 	}
-	for v := range s2.data {
-		result.data[v] = struct{}{}
+	result := &HashSet[K]{data: make(map[K]struct{}, n)}
+	for _, s := range sets {
+		for v := range s.data {
+			result.data[v] = struct{}{}
+		}
 	}
 	return result
 }
 
-// Intersection returns a new set that contains common elements from two sets
-func Intersection[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
+// Intersection returns a new set that contains elements common to all
+// the given sets. The intersection of no sets is empty.
+//
+// The smallest set is iterated first, so the cost is proportional to its
+// size rather than to the size of the largest set.
+func Intersection[K comparable](sets ...*HashSet[K]) *HashSet[K] {
 	result := New[K]()
-	for v := range s1.data {
-		if _, ok := s2.data[v]; ok {
+	if len(sets) == 0 {
+		return result
+	}
+	smallest := slices.MinFunc(sets, func(a, b *HashSet[K]) int {
+		return cmp.Compare(len(a.data), len(b.data))
+	})
+	for v := range smallest.data {
+		if containedInAll(v, sets) {
 			result.data[v] = struct{}{}
 		}
 	}
-
 	return result
 }
 
+func containedInAll[K comparable](v K, sets []*HashSet[K]) bool {
+	for _, s := range sets {
+		if _, ok := s.data[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // Difference returns a new set that contains elements
 // that are in the first set but not in the second set
 func Difference[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
@@ -118,3 +148,117 @@ func Difference[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
 
 	return result
 }
+
+// SymmetricDifference returns a new set that contains elements
+// that are in exactly one of the two sets
+func SymmetricDifference[K comparable](s1, s2 *HashSet[K]) *HashSet[K] {
+	result := New[K]()
+	for v := range s1.data {
+		if _, ok := s2.data[v]; !ok {
+			result.data[v] = struct{}{}
+		}
+	}
+	for v := range s2.data {
+		if _, ok := s1.data[v]; !ok {
+			result.data[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// IsSubset reports whether every element of s is in other
+func (s *HashSet[K]) IsSubset(other *HashSet[K]) bool {
+	if len(s.data) > len(other.data) {
+		return false
+	}
+	for v := range s.data {
+		if _, ok := other.data[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether every element of other is in s
+func (s *HashSet[K]) IsSuperset(other *HashSet[K]) bool {
+	return other.IsSubset(s)
+}
+
+// IsDisjoint reports whether s and other have no elements in common
+func (s *HashSet[K]) IsDisjoint(other *HashSet[K]) bool {
+	small, large := s, other
+	if len(small.data) > len(large.data) {
+		small, large = large, small
+	}
+	for v := range small.data {
+		if _, ok := large.data[v]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionWith adds all elements of others to s
+func (s *HashSet[K]) UnionWith(others ...*HashSet[K]) {
+	for _, o := range others {
+		for v := range o.data {
+			s.data[v] = struct{}{}
+		}
+	}
+}
+
+// IntersectWith removes the elements of s that are not in all of others
+func (s *HashSet[K]) IntersectWith(others ...*HashSet[K]) {
+	for v := range s.data {
+		if !containedInAll(v, others) {
+			delete(s.data, v)
+		}
+	}
+}
+
+// DifferenceWith removes the elements of s that are in any of others
+func (s *HashSet[K]) DifferenceWith(others ...*HashSet[K]) {
+	for _, o := range others {
+		for v := range o.data {
+			delete(s.data, v)
+		}
+	}
+}
+
+// Filter returns a new set that contains the elements of s
+// for which pred returns true
+func (s *HashSet[K]) Filter(pred func(K) bool) *HashSet[K] {
+	result := New[K]()
+	for v := range s.data {
+		if pred(v) {
+			result.data[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Partition returns two new sets that contain the elements of s
+// for which pred returns true and false, respectively
+func (s *HashSet[K]) Partition(pred func(K) bool) (in, out *HashSet[K]) {
+	in, out = New[K](), New[K]()
+	for v := range s.data {
+		if pred(v) {
+			in.data[v] = struct{}{}
+		} else {
+			out.data[v] = struct{}{}
+		}
+	}
+	return in, out
+}
+
+// Clone returns a copy of the set
+func (s *HashSet[K]) Clone() *HashSet[K] {
+	return &HashSet[K]{data: maps.Clone(s.data)}
+}
+
+// AddAll adds all elements from an iterator to the set
+func (s *HashSet[K]) AddAll(seq iter.Seq[K]) {
+	for v := range seq {
+		s.data[v] = struct{}{}
+	}
+}
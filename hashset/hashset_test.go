@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/linhns/gocontainers/containertest"
 	"github.com/linhns/gocontainers/hashset"
 )
 
@@ -97,3 +98,90 @@ func TestSetMathOperations(t *testing.T) {
 	difference := hashset.Collect(slices.Values([]int{5}))
 	assert.True(t, hashset.Equal(hashset.Difference(s1, s2), difference))
 }
+
+func TestSetAlgebra(t *testing.T) {
+	t.Parallel()
+
+	s1 := hashset.Collect(slices.Values([]int{1, 2, 4, 5}))
+	s2 := hashset.Collect(slices.Values([]int{1, 2, 3, 4}))
+	s3 := hashset.Collect(slices.Values([]int{2, 4, 6}))
+
+	symmetric := hashset.Collect(slices.Values([]int{3, 5}))
+	assert.True(t, hashset.Equal(hashset.SymmetricDifference(s1, s2), symmetric))
+
+	union := hashset.Collect(slices.Values([]int{1, 2, 3, 4, 5, 6}))
+	assert.True(t, hashset.Equal(hashset.Union(s1, s2, s3), union))
+	assert.True(t, hashset.Union[int]().Empty())
+
+	intersection := hashset.Collect(slices.Values([]int{2, 4}))
+	assert.True(t, hashset.Equal(hashset.Intersection(s1, s2, s3), intersection))
+	assert.True(t, hashset.Equal(hashset.Intersection(s1), s1))
+	assert.True(t, hashset.Intersection[int]().Empty())
+
+	assert.True(t, intersection.IsSubset(s1))
+	assert.True(t, s1.IsSubset(s1))
+	assert.False(t, s1.IsSubset(intersection))
+	assert.False(t, s3.IsSubset(union.Filter(func(v int) bool { return v != 6 })))
+	assert.True(t, s1.IsSuperset(intersection))
+	assert.False(t, intersection.IsSuperset(s1))
+
+	assert.False(t, s1.IsDisjoint(s3))
+	assert.True(t, symmetric.IsDisjoint(s3))
+	assert.True(t, hashset.New[int]().IsDisjoint(s1))
+}
+
+func TestSetInPlaceOperations(t *testing.T) {
+	t.Parallel()
+
+	s := hashset.Collect(slices.Values([]int{1, 2}))
+	s.UnionWith(hashset.Collect(slices.Values([]int{2, 3})), hashset.Collect(slices.Values([]int{4})))
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, slices.Collect(s.All()))
+
+	s.IntersectWith(hashset.Collect(slices.Values([]int{1, 2, 3})), hashset.Collect(slices.Values([]int{2, 3, 4})))
+	assert.ElementsMatch(t, []int{2, 3}, slices.Collect(s.All()))
+
+	s.DifferenceWith(hashset.Collect(slices.Values([]int{3})))
+	assert.ElementsMatch(t, []int{2}, slices.Collect(s.All()))
+
+	s.AddAll(slices.Values([]int{5, 6, 5}))
+	assert.ElementsMatch(t, []int{2, 5, 6}, slices.Collect(s.All()))
+
+	s.UnionWith(s)
+	s.IntersectWith(s)
+	assert.Equal(t, 3, s.Len())
+	s.AddAll(s.All())
+	assert.Equal(t, 3, s.Len())
+	s.DifferenceWith(s)
+	assert.True(t, s.Empty())
+}
+
+func TestSetFilterPartitionClone(t *testing.T) {
+	t.Parallel()
+
+	s := hashset.Collect(slices.Values([]int{1, 2, 3, 4, 5}))
+	even := func(v int) bool { return v%2 == 0 }
+
+	assert.ElementsMatch(t, []int{2, 4}, slices.Collect(s.Filter(even).All()))
+
+	in, out := s.Partition(even)
+	assert.ElementsMatch(t, []int{2, 4}, slices.Collect(in.All()))
+	assert.ElementsMatch(t, []int{1, 3, 5}, slices.Collect(out.All()))
+
+	c := s.Clone()
+	c.Add(6)
+	s.Remove(1)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6}, slices.Collect(c.All()))
+	assert.ElementsMatch(t, []int{2, 3, 4, 5}, slices.Collect(s.All()))
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestSet(t, hashset.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestSetModel(t, hashset.New[int])
+}
+
+func FuzzHashSet(f *testing.F) {
+	containertest.FuzzSet(f, hashset.New[int])
+}
@@ -0,0 +1,221 @@
+// Package lincheck records histories of concurrent operations and checks
+// them for linearizability against a sequential model.
+//
+// The checker implements the Wing & Gong search with the memoization
+// described by Lowe in "Testing for linearizability" (2017). Its running
+// time is exponential in the worst case, so histories should be kept to
+// a few dozen operations.
+package lincheck
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Operation is a completed operation in a history.
+//
+// Call and Return are logical timestamps taken from a clock shared by all
+// clients, so an operation that returned before another was invoked has
+// a smaller Return than the other's Call.
+type Operation[I, O any] struct {
+	Client int
+	Input  I
+	Output O
+	Call   int64
+	Return int64
+}
+
+// Model is a sequential specification of an object.
+type Model[S, I, O any] struct {
+	// Init returns the initial state of the object.
+	Init func() S
+	// Step reports whether applying input to state may produce output,
+	// and returns the state after the operation. Step must not modify
+	// state in place.
+	Step func(state S, input I, output O) (bool, S)
+}
+
+// Recorder records a history of operations performed concurrently by
+// several clients. It is safe for concurrent use.
+type Recorder[I, O any] struct {
+	clock atomic.Int64
+	mu    sync.Mutex
+	ops   []Operation[I, O]
+}
+
+// Record calls f, recording it as an operation with the given input
+// invoked by client, and returns its output.
+func (r *Recorder[I, O]) Record(client int, input I, f func() O) O {
+	call := r.clock.Add(1)
+	output := f()
+	ret := r.clock.Add(1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ops = append(r.ops, Operation[I, O]{
+		Client: client,
+		Input:  input,
+		Output: output,
+		Call:   call,
+		Return: ret,
+	})
+	return output
+}
+
+// History returns the operations recorded so far.
+func (r *Recorder[I, O]) History() []Operation[I, O] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.ops)
+}
+
+// Run starts clients goroutines at the same time, each calling f with
+// its client number and i = 0, 1, ..., n-1 in order, and waits for all
+// of them to finish.
+func Run(clients, n int, f func(client, i int)) {
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for client := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for i := range n {
+				f(client, i)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+}
+
+type entry struct {
+	op     int
+	isCall bool
+	match  *entry
+	prev   *entry
+	next   *entry
+}
+
+type frame[S any] struct {
+	entry *entry
+	state S
+}
+
+// Check reports whether history is linearizable with respect to model.
+func Check[S, I, O any](model Model[S, I, O], history []Operation[I, O]) bool {
+	head := buildList(history)
+	linearized := newBitset(len(history))
+	cache := make(map[string]bool)
+	var stack []frame[S]
+
+	state := model.Init()
+	e := head.next
+	for head.next != nil {
+		if e.isCall {
+			op := history[e.op]
+			ok, next := model.Step(state, op.Input, op.Output)
+			if ok {
+				linearized.set(e.op)
+				key := linearized.String() + "|" + fmt.Sprint(next)
+				if !cache[key] {
+					cache[key] = true
+					stack = append(stack, frame[S]{entry: e, state: state})
+					state = next
+					lift(e)
+					e = head.next
+					continue
+				}
+				linearized.clear(e.op)
+			}
+			e = e.next
+			continue
+		}
+
+		// A return was reached before its call could be linearized,
+		// so backtrack to the most recently linearized operation.
+		if len(stack) == 0 {
+			return false
+		}
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		state = top.state
+		linearized.clear(top.entry.op)
+		unlift(top.entry)
+		e = top.entry.next
+	}
+	return true
+}
+
+// buildList returns the head sentinel of a doubly linked list holding the
+// call and return events of history in timestamp order.
+func buildList[I, O any](history []Operation[I, O]) *entry {
+	type event struct {
+		time  int64
+		entry *entry
+	}
+	events := make([]event, 0, 2*len(history))
+	for i, op := range history {
+		call := &entry{op: i, isCall: true}
+		ret := &entry{op: i, match: call}
+		call.match = ret
+		events = append(events, event{op.Call, call}, event{op.Return, ret})
+	}
+	slices.SortFunc(events, func(a, b event) int {
+		return cmp.Compare(a.time, b.time)
+	})
+
+	head := &entry{}
+	prev := head
+	for _, ev := range events {
+		prev.next = ev.entry
+		ev.entry.prev = prev
+		prev = ev.entry
+	}
+	return head
+}
+
+// lift removes the call entry e and its matching return from the list.
+func lift(e *entry) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	m := e.match
+	m.prev.next = m.next
+	if m.next != nil {
+		m.next.prev = m.prev
+	}
+}
+
+// unlift undoes lift(e).
+func unlift(e *entry) {
+	m := e.match
+	m.prev.next = m
+	if m.next != nil {
+		m.next.prev = m
+	}
+	e.prev.next = e
+	e.next.prev = e
+}
+
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b bitset) clear(i int) {
+	b[i/64] &^= 1 << (i % 64)
+}
+
+func (b bitset) String() string {
+	return fmt.Sprint([]uint64(b))
+}
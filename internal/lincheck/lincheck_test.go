@@ -0,0 +1,101 @@
+package lincheck_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/internal/lincheck"
+)
+
+// registerInput is either a write of value or, if read is set, a read.
+type registerInput struct {
+	read  bool
+	value int
+}
+
+var registerModel = lincheck.Model[int, registerInput, int]{
+	Init: func() int { return 0 },
+	Step: func(state int, in registerInput, out int) (bool, int) {
+		if in.read {
+			return out == state, state
+		}
+		return true, in.value
+	},
+}
+
+func op(client int, in registerInput, out int, call, ret int64) lincheck.Operation[registerInput, int] {
+	return lincheck.Operation[registerInput, int]{
+		Client: client, Input: in, Output: out, Call: call, Return: ret,
+	}
+}
+
+func TestCheckLinearizable(t *testing.T) {
+	t.Parallel()
+
+	// The read overlaps the write, so it may observe either value.
+	history := []lincheck.Operation[registerInput, int]{
+		op(0, registerInput{value: 1}, 0, 1, 4),
+		op(1, registerInput{read: true}, 1, 2, 3),
+		op(2, registerInput{read: true}, 1, 5, 6),
+	}
+	assert.True(t, lincheck.Check(registerModel, history))
+
+	history[1].Output = 0
+	assert.True(t, lincheck.Check(registerModel, history))
+}
+
+func TestCheckNotLinearizable(t *testing.T) {
+	t.Parallel()
+
+	// The second read starts after the first one observed the write,
+	// so it must not observe the initial value.
+	history := []lincheck.Operation[registerInput, int]{
+		op(0, registerInput{value: 1}, 0, 1, 10),
+		op(1, registerInput{read: true}, 1, 2, 3),
+		op(2, registerInput{read: true}, 0, 4, 5),
+	}
+	assert.False(t, lincheck.Check(registerModel, history))
+
+	// A read completing before the write started cannot observe it.
+	history = []lincheck.Operation[registerInput, int]{
+		op(0, registerInput{read: true}, 1, 1, 2),
+		op(1, registerInput{value: 1}, 0, 3, 4),
+	}
+	assert.False(t, lincheck.Check(registerModel, history))
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rec lincheck.Recorder[registerInput, int]
+		mu  sync.Mutex
+		reg int
+	)
+	lincheck.Run(4, 5, func(client, i int) {
+		if i%2 == 0 {
+			in := registerInput{value: client*10 + i}
+			rec.Record(client, in, func() int {
+				mu.Lock()
+				defer mu.Unlock()
+				reg = in.value
+				return 0
+			})
+			return
+		}
+		rec.Record(client, registerInput{read: true}, func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return reg
+		})
+	})
+
+	history := rec.History()
+	assert.Len(t, history, 20)
+	for _, op := range history {
+		assert.Less(t, op.Call, op.Return)
+	}
+	assert.True(t, lincheck.Check(registerModel, history))
+}
@@ -0,0 +1,31 @@
+// Package testutil provides helpers shared by the tests of the concurrent
+// containers.
+package testutil
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// NoDeadlock runs f in a new goroutine and fails t if it does not finish
+// within a generous timeout.
+//
+// While f runs, GOMAXPROCS is raised to at least 4, so that the goroutines
+// f starts run in parallel even on machines with few CPUs.
+func NoDeadlock(t *testing.T, f func()) {
+	t.Helper()
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(max(4, runtime.NumCPU())))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("deadlock")
+	}
+}
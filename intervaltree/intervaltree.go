@@ -0,0 +1,430 @@
+// Package intervaltree provides an interval tree, a map from closed
+// intervals to values that finds the intervals overlapping an interval or
+// containing a point.
+package intervaltree
+
+import (
+	"iter"
+
+	"github.com/linhns/gocontainers/comparator"
+)
+
+// Interval is the closed interval [Lo, Hi].
+type Interval[K any] struct {
+	Lo, Hi K
+}
+
+// IntervalTree is a map from closed intervals to values, ordered by a
+// configurable comparision function (comparator) on the endpoints.
+//
+// It is a priority search tree: the intervals are the leaves of an AVL
+// tree ordered by low and then high endpoint, and each interval is also
+// stored in one node on the path to its leaf, so that no node stores an
+// interval with a higher high endpoint than the node above it. This lets
+// a query skip every subtree without an overlapping interval, reporting
+// the k intervals found in O(log n + k) time.
+type IntervalTree[K, V any] struct {
+	root *node[K, V]
+	len  int
+	// removed counts the leaves of removed intervals, which are kept to
+	// avoid rebalancing the tree until they outnumber the intervals.
+	removed    int
+	comparator comparator.Comparator[K]
+}
+
+type entry[K, V any] struct {
+	interval Interval[K]
+	value    V
+}
+
+type node[K, V any] struct {
+	// left and right are both nil for a leaf and both set otherwise.
+	left, right *node[K, V]
+	// last is the greatest interval in the subtree, used to route
+	// searches.
+	last   Interval[K]
+	height int
+	// entry is the entry of a leaf, or nil if it was removed.
+	entry *entry[K, V]
+	// item is the entry stored at the node by the priority search tree.
+	// If it is nil, so are the items of the nodes below.
+	item *entry[K, V]
+}
+
+// New creates a new [IntervalTree] with the specified comparator.
+func New[K, V any](comparator comparator.Comparator[K]) *IntervalTree[K, V] {
+	return &IntervalTree[K, V]{comparator: comparator}
+}
+
+// Len returns the number of intervals in the tree.
+func (t *IntervalTree[K, V]) Len() int {
+	return t.len
+}
+
+// Empty reports whether the tree is empty.
+func (t *IntervalTree[K, V]) Empty() bool {
+	return t.len == 0
+}
+
+// Clear removes all intervals from the tree.
+func (t *IntervalTree[K, V]) Clear() {
+	t.root = nil
+	t.len = 0
+	t.removed = 0
+}
+
+// Insert maps the interval [lo, hi] to value in O(log n) time, replacing
+// the value of an equal interval if there is one.
+//
+// If lo is greater than hi, Insert panics.
+func (t *IntervalTree[K, V]) Insert(lo, hi K, value V) {
+	if t.comparator(lo, hi) > 0 {
+		panic("intervaltree.Insert: lo greater than hi")
+	}
+	i := Interval[K]{lo, hi}
+	leaf := t.leaf(i)
+	switch {
+	case leaf != nil && leaf.entry != nil:
+		leaf.entry.value = value
+		return
+	case leaf != nil:
+		leaf.entry = &entry[K, V]{i, value}
+		t.removed--
+	default:
+		leaf = &node[K, V]{last: i, height: 1, entry: &entry[K, V]{i, value}}
+		t.root = t.insert(t.root, leaf)
+	}
+	t.len++
+	t.sift(t.root, leaf.entry)
+}
+
+// Get returns the value of the interval [lo, hi] in O(log n) time, if it
+// is in the tree.
+func (t *IntervalTree[K, V]) Get(lo, hi K) (V, bool) {
+	if leaf := t.leaf(Interval[K]{lo, hi}); leaf != nil && leaf.entry != nil {
+		return leaf.entry.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Remove removes the interval [lo, hi] from the tree in amortized
+// O(log n) time and reports whether it was in the tree.
+func (t *IntervalTree[K, V]) Remove(lo, hi K) bool {
+	i := Interval[K]{lo, hi}
+	leaf := t.leaf(i)
+	if leaf == nil || leaf.entry == nil {
+		return false
+	}
+	e := leaf.entry
+	n := t.root
+	for n.item != e {
+		n = t.child(n, i)
+	}
+	t.fill(n)
+	leaf.entry = nil
+	t.len--
+	t.removed++
+	if t.removed > t.len {
+		t.rebuild()
+	}
+	return true
+}
+
+// Overlapping returns an iterator over the intervals in the tree that
+// overlap [lo, hi], that is, that have at least one point in common with
+// it, and their values, in unspecified order.
+//
+// Finding k intervals takes O(log n + k) time.
+func (t *IntervalTree[K, V]) Overlapping(lo, hi K) iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		t.overlapping(t.root, lo, hi, yield)
+	}
+}
+
+// Containing returns an iterator over the intervals in the tree that
+// contain point and their values, in unspecified order.
+//
+// Finding k intervals takes O(log n + k) time.
+func (t *IntervalTree[K, V]) Containing(point K) iter.Seq2[Interval[K], V] {
+	return t.Overlapping(point, point)
+}
+
+// All returns an iterator over the intervals in the tree and their values,
+// ordered by low and then high endpoint.
+func (t *IntervalTree[K, V]) All() iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		all(t.root, yield)
+	}
+}
+
+// Merged returns an iterator over the union of the intervals in the tree
+// as disjoint intervals in ascending order. Overlapping intervals,
+// including those sharing only an endpoint, are merged.
+func (t *IntervalTree[K, V]) Merged() iter.Seq[Interval[K]] {
+	return func(yield func(Interval[K]) bool) {
+		var (
+			cur     Interval[K]
+			started bool
+		)
+		for i := range t.All() {
+			switch {
+			case !started:
+				cur, started = i, true
+			case t.comparator(i.Lo, cur.Hi) <= 0:
+				if t.comparator(i.Hi, cur.Hi) > 0 {
+					cur.Hi = i.Hi
+				}
+			default:
+				if !yield(cur) {
+					return
+				}
+				cur = i
+			}
+		}
+		if started {
+			yield(cur)
+		}
+	}
+}
+
+// compare orders intervals by low and then high endpoint.
+func (t *IntervalTree[K, V]) compare(a, b Interval[K]) int {
+	if c := t.comparator(a.Lo, b.Lo); c != 0 {
+		return c
+	}
+	return t.comparator(a.Hi, b.Hi)
+}
+
+// higher reports whether the high endpoint of a is greater than that of b.
+func (t *IntervalTree[K, V]) higher(a, b *entry[K, V]) bool {
+	return t.comparator(a.interval.Hi, b.interval.Hi) > 0
+}
+
+// child returns the child of the inner node n on the path to the leaf of
+// the interval i.
+func (t *IntervalTree[K, V]) child(n *node[K, V], i Interval[K]) *node[K, V] {
+	if t.compare(i, n.left.last) <= 0 {
+		return n.left
+	}
+	return n.right
+}
+
+// leaf returns the leaf of the interval i, or nil if there is none.
+func (t *IntervalTree[K, V]) leaf(i Interval[K]) *node[K, V] {
+	n := t.root
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = t.child(n, i)
+	}
+	if t.compare(i, n.last) != 0 {
+		return nil
+	}
+	return n
+}
+
+// insert adds the leaf to the subtree rooted at n, which has no leaf for
+// the same interval, and returns the new root of the subtree. The items
+// are kept valid, but the entry of the leaf is not stored in any node.
+func (t *IntervalTree[K, V]) insert(n, leaf *node[K, V]) *node[K, V] {
+	if n == nil {
+		return leaf
+	}
+	if n.left == nil {
+		// Replace n with an inner node holding n and the new leaf. The
+		// item of n, if any, is its entry, which may stay above it.
+		inner := &node[K, V]{left: n, right: leaf, item: n.item}
+		if t.compare(leaf.last, n.last) < 0 {
+			inner.left, inner.right = leaf, n
+		}
+		n.item = nil
+		t.update(inner)
+		return inner
+	}
+	if t.compare(leaf.last, n.left.last) <= 0 {
+		n.left = t.insert(n.left, leaf)
+	} else {
+		n.right = t.insert(n.right, leaf)
+	}
+	return t.rebalance(n)
+}
+
+// sift stores e, whose leaf is below n, in the first node without an item
+// on the path from n to the leaf, swapping it with the items of lower
+// priority on the way.
+func (t *IntervalTree[K, V]) sift(n *node[K, V], e *entry[K, V]) {
+	for e != nil {
+		if n.item == nil {
+			n.item = e
+			return
+		}
+		if t.higher(e, n.item) {
+			n.item, e = e, n.item
+		}
+		n = t.child(n, e.interval)
+	}
+}
+
+// fill removes the item of n and moves the items below up to take its
+// place.
+func (t *IntervalTree[K, V]) fill(n *node[K, V]) {
+	for {
+		n.item = nil
+		if n.left == nil {
+			return
+		}
+		c := n.left
+		if c.item == nil || n.right.item != nil && t.higher(n.right.item, c.item) {
+			c = n.right
+		}
+		if c.item == nil {
+			return
+		}
+		n.item = c.item
+		n = c
+	}
+}
+
+// rebuild rebuilds the tree without the leaves of removed intervals.
+func (t *IntervalTree[K, V]) rebuild() {
+	live := make([]*node[K, V], 0, t.len)
+	for n := range leaves(t.root) {
+		if n.entry != nil {
+			n.item = n.entry
+			live = append(live, n)
+		}
+	}
+	t.root = t.build(live)
+	t.removed = 0
+}
+
+// build returns the root of a balanced tree with the leaves, which are
+// ordered and hold their entries as items, in O(n) time.
+func (t *IntervalTree[K, V]) build(leaves []*node[K, V]) *node[K, V] {
+	switch len(leaves) {
+	case 0:
+		return nil
+	case 1:
+		return leaves[0]
+	}
+	mid := len(leaves) / 2
+	n := &node[K, V]{left: t.build(leaves[:mid]), right: t.build(leaves[mid:])}
+	t.update(n)
+	// Filling the empty node pulls up the highest item below it.
+	t.fill(n)
+	return n
+}
+
+func (t *IntervalTree[K, V]) overlapping(n *node[K, V], lo, hi K, yield func(Interval[K], V) bool) bool {
+	// No interval in the subtree ends at or after lo.
+	if n == nil || n.item == nil || t.comparator(n.item.interval.Hi, lo) < 0 {
+		return true
+	}
+	if t.comparator(n.item.interval.Lo, hi) <= 0 && !yield(n.item.interval, n.item.value) {
+		return false
+	}
+	if n.left == nil {
+		return true
+	}
+	if !t.overlapping(n.left, lo, hi, yield) {
+		return false
+	}
+	// The intervals in the right subtree start no earlier than the last
+	// one in the left subtree.
+	if t.comparator(n.left.last.Lo, hi) > 0 {
+		return true
+	}
+	return t.overlapping(n.right, lo, hi, yield)
+}
+
+// leaves returns an iterator over the leaves of the subtree rooted at n
+// in order.
+func leaves[K, V any](n *node[K, V]) iter.Seq[*node[K, V]] {
+	return func(yield func(*node[K, V]) bool) {
+		var walk func(n *node[K, V]) bool
+		walk = func(n *node[K, V]) bool {
+			if n == nil {
+				return true
+			}
+			if n.left == nil {
+				return yield(n)
+			}
+			return walk(n.left) && walk(n.right)
+		}
+		walk(n)
+	}
+}
+
+func all[K, V any](n *node[K, V], yield func(Interval[K], V) bool) {
+	for leaf := range leaves(n) {
+		if leaf.entry != nil && !yield(leaf.entry.interval, leaf.entry.value) {
+			return
+		}
+	}
+}
+
+// rebalance restores the AVL balance of the inner node n, whose subtrees
+// are balanced and differ in height by at most 2, and returns the new
+// root of the subtree.
+func (t *IntervalTree[K, V]) rebalance(n *node[K, V]) *node[K, V] {
+	switch balance := height(n.left) - height(n.right); {
+	case balance > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = t.rotateLeft(n.left)
+		}
+		return t.rotateRight(n)
+	case balance < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = t.rotateRight(n.right)
+		}
+		return t.rotateLeft(n)
+	}
+	t.update(n)
+	return n
+}
+
+func (t *IntervalTree[K, V]) rotateLeft(n *node[K, V]) *node[K, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	t.update(n)
+	t.update(r)
+	t.rotateItems(r, n)
+	return r
+}
+
+func (t *IntervalTree[K, V]) rotateRight(n *node[K, V]) *node[K, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	t.update(n)
+	t.update(l)
+	t.rotateItems(l, n)
+	return l
+}
+
+// rotateItems restores the items after a rotation that made top the
+// parent of its former parent n. The item of n, the highest of the
+// subtree, moves to top, and the former item of top is stored again.
+func (t *IntervalTree[K, V]) rotateItems(top, n *node[K, V]) {
+	e := top.item
+	top.item = n.item
+	t.fill(n)
+	if e != nil {
+		t.sift(top, e)
+	}
+}
+
+// update recomputes the height and last interval of the inner node n from
+// its children.
+func (t *IntervalTree[K, V]) update(n *node[K, V]) {
+	n.height = 1 + max(n.left.height, n.right.height)
+	n.last = n.right.last
+}
+
+func height[K, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
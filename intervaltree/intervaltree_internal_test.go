@@ -0,0 +1,130 @@
+package intervaltree
+
+import (
+	"cmp"
+	"math/bits"
+	"testing"
+	"testing/quick"
+)
+
+// isValid reports whether the tree is an ordered, balanced leaf-oriented
+// tree whose nodes record correct heights and last intervals, and whose
+// items form a heap on the high endpoints with every live entry stored
+// once on the path to its leaf.
+func (t *IntervalTree[K, V]) isValid() bool {
+	stored := make(map[*entry[K, V]]int)
+	var check func(n *node[K, V], parent *entry[K, V], path []*entry[K, V]) bool
+	check = func(n *node[K, V], parent *entry[K, V], path []*entry[K, V]) bool {
+		if n.item != nil {
+			if parent == nil || t.higher(n.item, parent) {
+				return false
+			}
+			stored[n.item]++
+			path = append(path, n.item)
+		}
+		if n.left == nil {
+			if n.height != 1 {
+				return false
+			}
+			// The entry of the leaf must be stored on the path to it.
+			if n.entry != nil {
+				found := false
+				for _, e := range path {
+					found = found || e == n.entry
+				}
+				return found
+			}
+			return true
+		}
+		if n.right == nil || n.height != 1+max(n.left.height, n.right.height) {
+			return false
+		}
+		if d := n.left.height - n.right.height; d < -1 || d > 1 {
+			return false
+		}
+		if t.compare(n.last, n.right.last) != 0 || t.compare(n.left.last, n.right.last) >= 0 {
+			return false
+		}
+		return check(n.left, n.item, path) && check(n.right, n.item, path)
+	}
+	if t.root != nil {
+		// A sentinel parent with the highest possible priority.
+		top := t.root.item
+		if !check(t.root, top, nil) {
+			return false
+		}
+	}
+
+	var prev *Interval[K]
+	live, removed := 0, 0
+	for leaf := range leaves(t.root) {
+		if prev != nil && t.compare(*prev, leaf.last) >= 0 {
+			return false
+		}
+		prev = &leaf.last
+		if leaf.entry == nil {
+			removed++
+			continue
+		}
+		live++
+		if stored[leaf.entry] != 1 {
+			return false
+		}
+	}
+	return live == t.len && removed == t.removed && len(stored) == t.len && removed <= live
+}
+
+func TestTreeInvariant(t *testing.T) {
+	prop := func(ops []uint8) bool {
+		tree := New[uint8, int](cmp.Compare[uint8])
+		for _, op := range ops {
+			lo, hi := op%32, op%32+op/64
+			if op%4 == 0 {
+				tree.Remove(lo, hi)
+			} else {
+				tree.Insert(lo, hi, 0)
+			}
+			if !tree.isValid() {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(prop, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestOverlappingBound checks that a query visits O(log n + k) nodes by
+// counting comparisons, with the few intervals found spread over a large
+// tree so that the paths to them share little.
+func TestOverlappingBound(t *testing.T) {
+	comparisons := 0
+	tree := New[int, int](func(a, b int) int {
+		comparisons++
+		return cmp.Compare(a, b)
+	})
+	const n, k = 1 << 14, 64
+	for i := range n {
+		tree.Insert(2*i, 2*i, i)
+	}
+	for i := range k {
+		tree.Insert(2*i*(n/k)+1, 2*n, i)
+	}
+
+	comparisons = 0
+	found := 0
+	for range tree.Containing(2*n - 1) {
+		found++
+	}
+	if found != k {
+		t.Fatalf("found %d intervals, want %d", found, k)
+	}
+	height := tree.root.height
+	if limit := 3 * (2*k + 2*height); comparisons > limit {
+		t.Errorf("query made %d comparisons, want at most %d", comparisons, limit)
+	}
+	if maxHeight := 2 * bits.Len(uint(n+k)); height > maxHeight {
+		t.Errorf("tree height is %d, want at most %d", height, maxHeight)
+	}
+}
@@ -0,0 +1,155 @@
+package intervaltree_test
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/intervaltree"
+)
+
+type interval = intervaltree.Interval[int]
+
+func keys[V any](seq iter.Seq2[interval, V]) []interval {
+	var result []interval
+	for i := range seq {
+		result = append(result, i)
+	}
+	return result
+}
+
+// sortedKeys returns the intervals of seq ordered by low and then high
+// endpoint.
+func sortedKeys[V any](seq iter.Seq2[interval, V]) []interval {
+	return slices.SortedFunc(slices.Values(keys(seq)), compareIntervals)
+}
+
+func compareIntervals(a, b interval) int {
+	return cmp.Or(cmp.Compare(a.Lo, b.Lo), cmp.Compare(a.Hi, b.Hi))
+}
+
+func TestIntervalTree(t *testing.T) {
+	tree := intervaltree.New[int, string](cmp.Compare[int])
+	assert.True(t, tree.Empty())
+
+	tree.Insert(15, 20, "a")
+	tree.Insert(10, 30, "b")
+	tree.Insert(17, 19, "c")
+	tree.Insert(5, 20, "d")
+	tree.Insert(12, 15, "e")
+	tree.Insert(30, 40, "f")
+	assert.Equal(t, 6, tree.Len())
+
+	assert.Equal(t, []interval{{5, 20}, {10, 30}, {12, 15}, {15, 20}, {17, 19}, {30, 40}}, keys(tree.All()))
+	assert.Equal(t, []interval{{5, 20}, {10, 30}, {12, 15}}, sortedKeys(tree.Overlapping(6, 14)))
+	assert.Equal(t, []interval{{10, 30}, {30, 40}}, sortedKeys(tree.Containing(30)))
+	assert.Empty(t, sortedKeys(tree.Overlapping(41, 50)))
+
+	for i, v := range tree.Containing(18) {
+		if i == (interval{15, 20}) {
+			assert.Equal(t, "a", v)
+		}
+	}
+
+	tree.Insert(15, 20, "z")
+	assert.Equal(t, 6, tree.Len())
+	v, ok := tree.Get(15, 20)
+	assert.True(t, ok)
+	assert.Equal(t, "z", v)
+
+	assert.True(t, tree.Remove(10, 30))
+	assert.False(t, tree.Remove(10, 30))
+	_, ok = tree.Get(10, 30)
+	assert.False(t, ok)
+	assert.Equal(t, []interval{{5, 20}, {15, 20}, {17, 19}}, sortedKeys(tree.Containing(18)))
+
+	tree.Clear()
+	assert.True(t, tree.Empty())
+	assert.Empty(t, keys(tree.All()))
+}
+
+func TestMerged(t *testing.T) {
+	tree := intervaltree.New[int, struct{}](cmp.Compare[int])
+	assert.Empty(t, slices.Collect(tree.Merged()))
+
+	for _, i := range []interval{{8, 10}, {1, 3}, {2, 6}, {15, 18}, {10, 12}, {4, 5}, {20, 20}} {
+		tree.Insert(i.Lo, i.Hi, struct{}{})
+	}
+	assert.Equal(t, []interval{{1, 6}, {8, 12}, {15, 18}, {20, 20}}, slices.Collect(tree.Merged()))
+
+	for i := range tree.Merged() {
+		assert.Equal(t, interval{1, 6}, i)
+		break
+	}
+}
+
+func TestInsertPanics(t *testing.T) {
+	tree := intervaltree.New[int, int](cmp.Compare[int])
+	assert.PanicsWithValue(t, "intervaltree.Insert: lo greater than hi", func() {
+		tree.Insert(2, 1, 0)
+	})
+}
+
+func TestIntervalTreeModel(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	tree := intervaltree.New[int, int](cmp.Compare[int])
+	model := make(map[interval]int)
+
+	randInterval := func() interval {
+		lo := r.IntN(100)
+		return interval{lo, lo + r.IntN(20)}
+	}
+	sorted := func(pred func(interval) bool) []interval {
+		var result []interval
+		for i := range model {
+			if pred(i) {
+				result = append(result, i)
+			}
+		}
+		slices.SortFunc(result, compareIntervals)
+		return result
+	}
+
+	for op := range 5000 {
+		i := randInterval()
+		switch r.IntN(4) {
+		case 0, 1:
+			tree.Insert(i.Lo, i.Hi, op)
+			model[i] = op
+		case 2:
+			_, want := model[i]
+			assert.Equal(t, want, tree.Remove(i.Lo, i.Hi))
+			delete(model, i)
+		case 3:
+			want := sorted(func(m interval) bool { return m.Lo <= i.Hi && i.Lo <= m.Hi })
+			var got []interval
+			for g, v := range tree.Overlapping(i.Lo, i.Hi) {
+				assert.Equal(t, model[g], v)
+				got = append(got, g)
+			}
+			slices.SortFunc(got, compareIntervals)
+			assert.Equal(t, want, got)
+		}
+		assert.Equal(t, len(model), tree.Len())
+	}
+	assert.Equal(t, sorted(func(interval) bool { return true }), keys(tree.All()))
+}
+
+func BenchmarkOverlapping(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 2))
+	tree := intervaltree.New[int, int](cmp.Compare[int])
+	for i := range 1 << 16 {
+		lo := r.IntN(1 << 24)
+		tree.Insert(lo, lo+r.IntN(1<<10), i)
+	}
+	b.ResetTimer()
+	for range b.N {
+		lo := r.IntN(1 << 24)
+		for range tree.Overlapping(lo, lo+1<<10) {
+		}
+	}
+}
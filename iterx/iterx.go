@@ -0,0 +1,261 @@
+// Package iterx provides lazy adapters and consumers for iterators
+// produced by the containers in this module.
+package iterx
+
+import (
+	"iter"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/hashmap"
+)
+
+// Map returns an iterator that yields f applied to each element of seq.
+func Map[T, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns an iterator that yields the elements of seq
+// for which pred returns true.
+func Filter[T any](seq iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if pred(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMap returns an iterator that yields the elements of each iterator
+// returned by f for the elements of seq, in order.
+func FlatMap[T, U any](seq iter.Seq[T], f func(T) iter.Seq[U]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			for u := range f(v) {
+				if !yield(u) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Take returns an iterator that yields at most the first n elements of seq.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns an iterator that yields the elements of seq
+// after the first n.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile returns an iterator that yields the elements of seq
+// until pred returns false for the first time.
+func TakeWhile[T any](seq iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if !pred(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Zip returns an iterator over pairs of elements from s1 and s2.
+// It stops as soon as either iterator is exhausted.
+func Zip[T, U any](s1 iter.Seq[T], s2 iter.Seq[U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		next, stop := iter.Pull(s2)
+		defer stop()
+
+		for v := range s1 {
+			u, ok := next()
+			if !ok || !yield(v, u) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate returns an iterator over index-value pairs of seq,
+// with indices starting from zero.
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Chunk returns an iterator over consecutive, non-overlapping chunks of
+// up to n elements of seq. All chunks except possibly the last have
+// exactly n elements. Each chunk is a newly allocated slice.
+//
+// Chunk panics if n is less than 1.
+func Chunk[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic("iterx.Chunk: non-positive size")
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, n)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, n)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Window returns an iterator over all overlapping windows of exactly n
+// consecutive elements of seq. If seq has fewer than n elements, nothing
+// is yielded. Each window is a newly allocated slice.
+//
+// Window panics if n is less than 1.
+func Window[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic("iterx.Window: non-positive size")
+	}
+	return func(yield func([]T) bool) {
+		buf := make([]T, 0, n)
+		for v := range seq {
+			if len(buf) == n {
+				buf = buf[1:]
+			}
+			buf = append(buf, v)
+			if len(buf) == n {
+				window := make([]T, n)
+				copy(window, buf)
+				if !yield(window) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Reduce combines the elements of seq from left to right using f.
+// If seq is empty, it returns the zero value of T and false.
+func Reduce[T any](seq iter.Seq[T], f func(acc, v T) T) (T, bool) {
+	var acc T
+	first := true
+	for v := range seq {
+		if first {
+			acc = v
+			first = false
+			continue
+		}
+		acc = f(acc, v)
+	}
+	return acc, !first
+}
+
+// Fold combines the elements of seq from left to right using f,
+// starting from init.
+func Fold[T, A any](seq iter.Seq[T], init A, f func(acc A, v T) A) A {
+	acc := init
+	for v := range seq {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Any reports whether pred returns true for at least one element of seq.
+func Any[T any](seq iter.Seq[T], pred func(T) bool) bool {
+	for v := range seq {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// All reports whether pred returns true for every element of seq.
+// It returns true if seq is empty.
+func All[T any](seq iter.Seq[T], pred func(T) bool) bool {
+	for v := range seq {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Min returns the minimal element of seq according to cmp.
+// If there are several minimal elements, the first one is returned.
+// If seq is empty, it returns the zero value of T and false.
+func Min[T any](seq iter.Seq[T], cmp comparator.Comparator[T]) (T, bool) {
+	return Reduce(seq, func(acc, v T) T {
+		if cmp(v, acc) < 0 {
+			return v
+		}
+		return acc
+	})
+}
+
+// Max returns the maximal element of seq according to cmp.
+// If there are several maximal elements, the first one is returned.
+// If seq is empty, it returns the zero value of T and false.
+func Max[T any](seq iter.Seq[T], cmp comparator.Comparator[T]) (T, bool) {
+	return Reduce(seq, func(acc, v T) T {
+		if cmp(v, acc) > 0 {
+			return v
+		}
+		return acc
+	})
+}
+
+// GroupBy groups the elements of seq by the key returned by key.
+// The elements of each group keep their relative order in seq.
+func GroupBy[T any, K comparable](seq iter.Seq[T], key func(T) K) *hashmap.HashMap[K, []T] {
+	m := hashmap.New[K, []T]()
+	for v := range seq {
+		k := key(v)
+		group, _ := m.Get(k)
+		m.Insert(k, append(group, v))
+	}
+	return m
+}
@@ -0,0 +1,150 @@
+package iterx_test
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/iterx"
+	"github.com/linhns/gocontainers/vector"
+)
+
+func TestMapFilter(t *testing.T) {
+	t.Parallel()
+
+	v := vector.Of(1, 2, 3, 4, 5, 6)
+	even := iterx.Filter(v.Values(), func(x int) bool { return x%2 == 0 })
+	squares := iterx.Map(even, func(x int) int { return x * x })
+
+	assert.Equal(t, []int{4, 16, 36}, slices.Collect(squares))
+}
+
+func TestFlatMap(t *testing.T) {
+	t.Parallel()
+
+	v := vector.Of(1, 2, 3)
+	got := iterx.FlatMap(v.Values(), func(x int) iter.Seq[int] {
+		return slices.Values(slices.Repeat([]int{x}, x))
+	})
+
+	assert.Equal(t, []int{1, 2, 2, 3, 3, 3}, slices.Collect(got))
+	assert.Equal(t, []int{1, 2}, slices.Collect(iterx.Take(got, 2)))
+}
+
+func TestTakeSkip(t *testing.T) {
+	t.Parallel()
+
+	v := vector.Of(1, 2, 3, 4, 5)
+
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(iterx.Take(v.Values(), 3)))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, slices.Collect(iterx.Take(v.Values(), 10)))
+	assert.Empty(t, slices.Collect(iterx.Take(v.Values(), 0)))
+
+	assert.Equal(t, []int{4, 5}, slices.Collect(iterx.Skip(v.Values(), 3)))
+	assert.Empty(t, slices.Collect(iterx.Skip(v.Values(), 10)))
+
+	lessThan3 := func(x int) bool { return x < 3 }
+	assert.Equal(t, []int{1, 2}, slices.Collect(iterx.TakeWhile(v.Values(), lessThan3)))
+}
+
+func TestZipEnumerate(t *testing.T) {
+	t.Parallel()
+
+	nums := vector.Of(1, 2, 3)
+	words := vector.Of("one", "two")
+
+	var gotNums []int
+	var gotWords []string
+	for n, w := range iterx.Zip(nums.Values(), words.Values()) {
+		gotNums = append(gotNums, n)
+		gotWords = append(gotWords, w)
+	}
+	assert.Equal(t, []int{1, 2}, gotNums)
+	assert.Equal(t, []string{"one", "two"}, gotWords)
+
+	for i, w := range iterx.Enumerate(words.Values()) {
+		want, _ := words.Get(i)
+		assert.Equal(t, want, w)
+	}
+}
+
+func TestChunkWindow(t *testing.T) {
+	t.Parallel()
+
+	v := vector.Of(1, 2, 3, 4, 5)
+
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, slices.Collect(iterx.Chunk(v.Values(), 2)))
+	assert.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, slices.Collect(iterx.Window(v.Values(), 3)))
+	assert.Empty(t, slices.Collect(iterx.Window(v.Values(), 6)))
+
+	assert.Panics(t, func() { iterx.Chunk(v.Values(), 0) })
+	assert.Panics(t, func() { iterx.Window(v.Values(), 0) })
+}
+
+func TestReduceFold(t *testing.T) {
+	t.Parallel()
+
+	v := vector.Of(1, 2, 3, 4)
+	add := func(a, b int) int { return a + b }
+
+	sum, ok := iterx.Reduce(v.Values(), add)
+	assert.True(t, ok)
+	assert.Equal(t, 10, sum)
+
+	_, ok = iterx.Reduce(vector.New[int]().Values(), add)
+	assert.False(t, ok)
+
+	joined := iterx.Fold(v.Values(), "", func(acc string, x int) string {
+		return acc + strings.Repeat("*", x)
+	})
+	assert.Equal(t, 10, len(joined))
+}
+
+func TestAnyAll(t *testing.T) {
+	t.Parallel()
+
+	v := vector.Of(2, 4, 6)
+	even := func(x int) bool { return x%2 == 0 }
+	big := func(x int) bool { return x > 5 }
+
+	assert.True(t, iterx.All(v.Values(), even))
+	assert.False(t, iterx.All(v.Values(), big))
+	assert.True(t, iterx.Any(v.Values(), big))
+	assert.False(t, iterx.Any(vector.New[int]().Values(), even))
+}
+
+func TestMinMax(t *testing.T) {
+	t.Parallel()
+
+	v := vector.Of(3, 1, 4, 1, 5, 9, 2, 6)
+
+	lo, ok := iterx.Min(v.Values(), cmp.Compare[int])
+	assert.True(t, ok)
+	assert.Equal(t, 1, lo)
+
+	hi, ok := iterx.Max(v.Values(), cmp.Compare[int])
+	assert.True(t, ok)
+	assert.Equal(t, 9, hi)
+
+	_, ok = iterx.Min(vector.New[int]().Values(), cmp.Compare[int])
+	assert.False(t, ok)
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	v := vector.Of("apple", "avocado", "banana", "blueberry", "cherry")
+	groups := iterx.GroupBy(v.Values(), func(s string) byte { return s[0] })
+
+	assert.Equal(t, 3, groups.Len())
+	a, _ := groups.Get('a')
+	assert.Equal(t, []string{"apple", "avocado"}, a)
+	b, _ := groups.Get('b')
+	assert.Equal(t, []string{"banana", "blueberry"}, b)
+	c, _ := groups.Get('c')
+	assert.Equal(t, []string{"cherry"}, c)
+}
@@ -0,0 +1,271 @@
+// Package minmaxheap provides a double-ended priority queue based on
+// a min-max heap.
+package minmaxheap
+
+import (
+	"iter"
+	"math/bits"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
+)
+
+// MinMaxHeap is a double-ended priority queue with a configurable
+// comparision function (comparator). Both the minimum and the maximum
+// element can be inspected in O(1) time and removed in O(log n) time.
+//
+// Elements on even levels of the heap are smaller than their descendants
+// and elements on odd levels are greater than their descendants.
+//
+// Pop and Top operate on the maximum, like [priorityqueue.PriorityQueue].
+//
+// [priorityqueue.PriorityQueue]: https://pkg.go.dev/github.com/linhns/gocontainers/priorityqueue#PriorityQueue
+type MinMaxHeap[T any] struct {
+	data       []T
+	comparator comparator.Comparator[T]
+	capacity   int
+}
+
+var _ container.PriorityQueue[int] = (*MinMaxHeap[int])(nil)
+
+// New creates a new [MinMaxHeap] with the specified comparator.
+func New[T any](comparator comparator.Comparator[T]) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{
+		data:       []T{},
+		comparator: comparator,
+	}
+}
+
+// NewBounded creates a new [MinMaxHeap] with the specified comparator
+// that holds at most capacity elements. Pushing an element into a full
+// heap evicts the minimum, so the heap keeps the capacity largest
+// elements pushed into it.
+//
+// If capacity is not positive, NewBounded panics.
+func NewBounded[T any](comparator comparator.Comparator[T], capacity int) *MinMaxHeap[T] {
+	if capacity <= 0 {
+		panic("minmaxheap.NewBounded: non-positive capacity")
+	}
+	return &MinMaxHeap[T]{
+		data:       make([]T, 0, capacity),
+		comparator: comparator,
+		capacity:   capacity,
+	}
+}
+
+// Len returns the number of elements in the heap.
+func (h *MinMaxHeap[T]) Len() int {
+	return len(h.data)
+}
+
+// Cap returns the maximum number of elements of a bounded heap, or 0 if
+// the heap is unbounded.
+func (h *MinMaxHeap[T]) Cap() int {
+	return h.capacity
+}
+
+// Empty reports whether the heap is empty.
+func (h *MinMaxHeap[T]) Empty() bool {
+	return len(h.data) == 0
+}
+
+// Clear removes all elements from the heap.
+func (h *MinMaxHeap[T]) Clear() {
+	clear(h.data)
+	h.data = h.data[:0]
+}
+
+// Push adds an element to the heap. If the heap is bounded and full, the
+// minimum of the heap and v is evicted.
+func (h *MinMaxHeap[T]) Push(v T) {
+	h.Offer(v)
+}
+
+// Offer adds an element to the heap, like Push. If the heap is bounded
+// and was full, it returns the evicted element, which may be v itself,
+// and true.
+func (h *MinMaxHeap[T]) Offer(v T) (evicted T, ok bool) {
+	if h.capacity > 0 && len(h.data) == h.capacity {
+		if h.comparator(v, h.data[0]) <= 0 {
+			return v, true
+		}
+		evicted = h.data[0]
+		h.data[0] = v
+		h.trickleDown(0)
+		return evicted, true
+	}
+	h.data = append(h.data, v)
+	h.bubbleUp(len(h.data) - 1)
+	return
+}
+
+// PeekMin returns the minimum element of the heap.
+// If the heap is empty, it returns the zero value of the element type
+// and false.
+func (h *MinMaxHeap[T]) PeekMin() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[0], true
+}
+
+// PeekMax returns the maximum element of the heap.
+// If the heap is empty, it returns the zero value of the element type
+// and false.
+func (h *MinMaxHeap[T]) PeekMax() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[h.maxIndex()], true
+}
+
+// PopMin returns the minimum element of the heap, and removes it from
+// the heap. If the heap is empty, it returns the zero value of the element
+// type and false.
+func (h *MinMaxHeap[T]) PopMin() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(0), true
+}
+
+// PopMax returns the maximum element of the heap, and removes it from
+// the heap. If the heap is empty, it returns the zero value of the element
+// type and false.
+func (h *MinMaxHeap[T]) PopMax() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(h.maxIndex()), true
+}
+
+// Top is an alias for [MinMaxHeap.PeekMax].
+func (h *MinMaxHeap[T]) Top() (T, bool) {
+	return h.PeekMax()
+}
+
+// Pop is an alias for [MinMaxHeap.PopMax].
+func (h *MinMaxHeap[T]) Pop() (T, bool) {
+	return h.PopMax()
+}
+
+// All returns an iterator over the elements in the heap in unspecified
+// order.
+func (h *MinMaxHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range h.data {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect collects values from an iterator into a new [MinMaxHeap]
+// ordered by comparator.
+func Collect[T any](seq iter.Seq[T], comparator comparator.Comparator[T]) *MinMaxHeap[T] {
+	h := New(comparator)
+	for v := range seq {
+		h.Push(v)
+	}
+	return h
+}
+
+// maxIndex returns the index of the maximum of a non-empty heap, which is
+// the root or the greater of its children.
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch len(h.data) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.comparator(h.data[1], h.data[2]) >= 0 {
+		return 1
+	}
+	return 2
+}
+
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	v := h.data[i]
+	last := len(h.data) - 1
+	h.data[i] = h.data[last]
+	var zero T
+	h.data[last] = zero
+	h.data = h.data[:last]
+	if i < last {
+		h.trickleDown(i)
+	}
+	return v
+}
+
+// isMinLevel reports whether index i is on a min level of the heap.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// before reports whether the element at i belongs above the element at j
+// on a min level if onMin is true, or on a max level otherwise.
+func (h *MinMaxHeap[T]) before(i, j int, onMin bool) bool {
+	c := h.comparator(h.data[i], h.data[j])
+	if onMin {
+		return c < 0
+	}
+	return c > 0
+}
+
+func (h *MinMaxHeap[T]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+}
+
+func (h *MinMaxHeap[T]) bubbleUp(i int) {
+	if i == 0 {
+		return
+	}
+	onMin := isMinLevel(i)
+	parent := (i - 1) / 2
+	if h.before(parent, i, onMin) {
+		// The element belongs on the levels of the other kind.
+		h.swap(i, parent)
+		i, onMin = parent, !onMin
+	}
+	for i >= 3 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if !h.before(i, grandparent, onMin) {
+			return
+		}
+		h.swap(i, grandparent)
+		i = grandparent
+	}
+}
+
+func (h *MinMaxHeap[T]) trickleDown(i int) {
+	onMin := isMinLevel(i)
+	for {
+		// Find the best of the children and grandchildren of i.
+		m := -1
+		first := 2*i + 1
+		for _, c := range [...]int{first, first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4} {
+			if c < len(h.data) && (m < 0 || h.before(c, m, onMin)) {
+				m = c
+			}
+		}
+		if m < 0 || !h.before(m, i, onMin) {
+			return
+		}
+		h.swap(i, m)
+		if m <= first+1 {
+			// m is a child, on a level of the other kind, where the
+			// element moved down is in place.
+			return
+		}
+		if parent := (m - 1) / 2; h.before(parent, m, onMin) {
+			h.swap(m, parent)
+		}
+		i = m
+	}
+}
@@ -0,0 +1,51 @@
+package minmaxheap
+
+import (
+	"cmp"
+	"testing"
+	"testing/quick"
+)
+
+// isMinMaxHeap reports whether h.data satisfies the min-max heap
+// invariant: no element on a min level is greater than its descendants
+// and no element on a max level is smaller than its descendants.
+func (h *MinMaxHeap[T]) isMinMaxHeap() bool {
+	for i := 1; i < len(h.data); i++ {
+		for a := (i - 1) / 2; ; a = (a - 1) / 2 {
+			c := h.comparator(h.data[i], h.data[a])
+			if isMinLevel(a) && c < 0 || !isMinLevel(a) && c > 0 {
+				return false
+			}
+			if a == 0 {
+				break
+			}
+		}
+	}
+	return true
+}
+
+func TestHeapInvariant(t *testing.T) {
+	prop := func(ops []int8, capacity uint8) bool {
+		h := New(cmp.Compare[int8])
+		if capacity%2 == 0 {
+			h = NewBounded(cmp.Compare[int8], int(capacity%16)+1)
+		}
+		for _, op := range ops {
+			switch {
+			case op < 0 && op%3 == 0:
+				h.PopMin()
+			case op < 0 && op%3 == -1:
+				h.PopMax()
+			default:
+				h.Push(op)
+			}
+			if !h.isMinMaxHeap() {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(prop, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
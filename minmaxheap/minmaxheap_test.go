@@ -0,0 +1,134 @@
+package minmaxheap_test
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/minmaxheap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMaxHeapBasics(t *testing.T) {
+	h := minmaxheap.New(cmp.Compare[int])
+	assert.True(t, h.Empty())
+	_, ok := h.PeekMin()
+	assert.False(t, ok)
+	_, ok = h.PeekMax()
+	assert.False(t, ok)
+	_, ok = h.PopMin()
+	assert.False(t, ok)
+	_, ok = h.PopMax()
+	assert.False(t, ok)
+
+	for _, v := range []int{5, 3, 8, 1, 9, 2} {
+		h.Push(v)
+	}
+	assert.Equal(t, 6, h.Len())
+	assert.Equal(t, 0, h.Cap())
+
+	v, _ := h.PeekMin()
+	assert.Equal(t, 1, v)
+	v, _ = h.PeekMax()
+	assert.Equal(t, 9, v)
+
+	v, _ = h.PopMin()
+	assert.Equal(t, 1, v)
+	v, _ = h.PopMax()
+	assert.Equal(t, 9, v)
+	v, _ = h.PopMin()
+	assert.Equal(t, 2, v)
+	v, _ = h.Pop()
+	assert.Equal(t, 8, v)
+	v, _ = h.Top()
+	assert.Equal(t, 5, v)
+	assert.ElementsMatch(t, []int{3, 5}, slices.Collect(h.All()))
+
+	h.Clear()
+	assert.True(t, h.Empty())
+}
+
+func TestMinMaxHeapRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	h := minmaxheap.New(cmp.Compare[int])
+	var model []int
+	for range 10000 {
+		switch r.IntN(4) {
+		case 0:
+			v, ok := h.PopMin()
+			if len(model) == 0 {
+				assert.False(t, ok)
+				continue
+			}
+			assert.Equal(t, model[0], v)
+			model = model[1:]
+		case 1:
+			v, ok := h.PopMax()
+			if len(model) == 0 {
+				assert.False(t, ok)
+				continue
+			}
+			assert.Equal(t, model[len(model)-1], v)
+			model = model[:len(model)-1]
+		default:
+			v := r.IntN(100)
+			h.Push(v)
+			i, _ := slices.BinarySearch(model, v)
+			model = slices.Insert(model, i, v)
+		}
+		assert.Equal(t, len(model), h.Len())
+	}
+}
+
+func TestBounded(t *testing.T) {
+	h := minmaxheap.NewBounded(cmp.Compare[int], 3)
+	assert.Equal(t, 3, h.Cap())
+
+	for _, v := range []int{4, 1, 7} {
+		_, evicted := h.Offer(v)
+		assert.False(t, evicted)
+	}
+	v, evicted := h.Offer(5)
+	assert.True(t, evicted)
+	assert.Equal(t, 1, v)
+	v, evicted = h.Offer(2)
+	assert.True(t, evicted)
+	assert.Equal(t, 2, v)
+
+	for _, v := range rand.Perm(100) {
+		h.Push(v)
+	}
+	assert.Equal(t, 3, h.Len())
+	var got []int
+	for !h.Empty() {
+		v, _ := h.PopMin()
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{97, 98, 99}, got)
+
+	assert.PanicsWithValue(t, "minmaxheap.NewBounded: non-positive capacity", func() {
+		minmaxheap.NewBounded(cmp.Compare[int], 0)
+	})
+}
+
+func TestCollect(t *testing.T) {
+	h := minmaxheap.Collect(slices.Values([]int{3, 1, 2}), cmp.Compare[int])
+	v, _ := h.PeekMin()
+	assert.Equal(t, 1, v)
+	v, _ = h.PeekMax()
+	assert.Equal(t, 3, v)
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestPriorityQueue(t, minmaxheap.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestPriorityQueueModel(t, minmaxheap.New[int])
+}
+
+func FuzzMinMaxHeap(f *testing.F) {
+	containertest.FuzzPriorityQueue(f, minmaxheap.New[int])
+}
@@ -0,0 +1,267 @@
+// Package pairingheap provides a generic priority queue implementation
+// based on a pairing heap.
+//
+// A pairing heap supports pushing, melding and increasing the priority of
+// an element in O(1) time, and popping in O(log n) amortized time, which
+// makes it a good fit for algorithms with many decrease-key operations,
+// such as Dijkstra's shortest paths.
+package pairingheap
+
+import (
+	"iter"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
+)
+
+// Element is a handle to an element of a [PairingHeap], used to update or
+// remove it.
+type Element[T any] struct {
+	value T
+	// child is the leftmost child. prev is the previous sibling, or the
+	// parent of a leftmost child.
+	child, sibling, prev *Element[T]
+	inHeap               bool
+}
+
+// Value returns the value of the element.
+func (e *Element[T]) Value() T {
+	return e.value
+}
+
+// PairingHeap is a generic priority queue with a configurable comparision
+// function (comparator).
+type PairingHeap[T any] struct {
+	root       *Element[T]
+	len        int
+	comparator comparator.Comparator[T]
+}
+
+var _ container.PriorityQueue[int] = (*PairingHeap[int])(nil)
+
+// New creates a new [PairingHeap] with the specified comparator.
+func New[T any](comparator comparator.Comparator[T]) *PairingHeap[T] {
+	return &PairingHeap[T]{comparator: comparator}
+}
+
+// Collect collects values from an iterator into a new [PairingHeap]
+// ordered by comparator.
+func Collect[T any](seq iter.Seq[T], comparator comparator.Comparator[T]) *PairingHeap[T] {
+	h := New(comparator)
+	for v := range seq {
+		h.Push(v)
+	}
+	return h
+}
+
+// Len returns the number of elements in the heap.
+func (h *PairingHeap[T]) Len() int {
+	return h.len
+}
+
+// Empty reports whether the heap is empty.
+func (h *PairingHeap[T]) Empty() bool {
+	return h.len == 0
+}
+
+// Clear removes all elements from the heap. The elements previously in
+// the heap must not be used afterwards.
+func (h *PairingHeap[T]) Clear() {
+	h.root = nil
+	h.len = 0
+}
+
+// Push adds an element to the heap.
+func (h *PairingHeap[T]) Push(v T) {
+	h.Insert(v)
+}
+
+// Insert adds an element to the heap and returns a handle to it.
+func (h *PairingHeap[T]) Insert(v T) *Element[T] {
+	e := &Element[T]{value: v, inHeap: true}
+	h.root = h.meld(h.root, e)
+	h.len++
+	return e
+}
+
+// Top returns the element with the maximal priority in the heap.
+// If the heap is empty, it returns the zero value of the element type
+// and false.
+func (h *PairingHeap[T]) Top() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// TopElement returns a handle to the element with the maximal priority
+// in the heap, or nil if the heap is empty.
+func (h *PairingHeap[T]) TopElement() *Element[T] {
+	return h.root
+}
+
+// Pop returns the element with the maximal priority in the heap, and
+// removes it from the heap. If the heap is empty, it returns the zero
+// value of the element type and false.
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	e := h.root
+	h.root = h.mergePairs(e.child)
+	h.detach(e)
+	return e.value, true
+}
+
+// Update changes the value of e, which must be in h, and restores the
+// heap order.
+//
+// Raising the priority of e takes O(1) time and lowering it O(log n)
+// amortized time.
+//
+// If e is not in the heap, Update panics.
+func (h *PairingHeap[T]) Update(e *Element[T], v T) {
+	if !e.inHeap {
+		panic("pairingheap.Update: element not in heap")
+	}
+	old := e.value
+	e.value = v
+	if h.comparator(v, old) >= 0 {
+		if e != h.root {
+			h.cut(e)
+			h.root = h.meld(h.root, e)
+		}
+		return
+	}
+
+	// The children of e may now have a higher priority than e, so
+	// reinsert e on its own.
+	if e == h.root {
+		h.root = h.mergePairs(e.child)
+	} else {
+		h.cut(e)
+		h.root = h.meld(h.root, h.mergePairs(e.child))
+	}
+	e.child = nil
+	h.root = h.meld(h.root, e)
+}
+
+// Remove removes e, which must be in h, from the heap in O(log n)
+// amortized time.
+//
+// If e is not in the heap, Remove panics.
+func (h *PairingHeap[T]) Remove(e *Element[T]) {
+	if !e.inHeap {
+		panic("pairingheap.Remove: element not in heap")
+	}
+	if e == h.root {
+		h.root = h.mergePairs(e.child)
+	} else {
+		h.cut(e)
+		h.root = h.meld(h.root, h.mergePairs(e.child))
+	}
+	h.detach(e)
+}
+
+// Meld moves all elements of other into h in O(1) time, leaving other
+// empty. The handles of the elements of other remain valid for h.
+//
+// The subtrees of other are linked into h as they are, so other must order
+// elements the same way as h, for example by having been created with the
+// same comparator. Otherwise the order in which h pops elements is
+// unspecified.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if h == other {
+		return
+	}
+	h.root = h.meld(h.root, other.root)
+	h.len += other.len
+	other.root = nil
+	other.len = 0
+}
+
+// All returns an iterator over the elements in the heap in unspecified
+// order.
+func (h *PairingHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var stack []*Element[T]
+		if h.root != nil {
+			stack = append(stack, h.root)
+		}
+		for len(stack) > 0 {
+			e := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(e.value) {
+				return
+			}
+			for c := e.child; c != nil; c = c.sibling {
+				stack = append(stack, c)
+			}
+		}
+	}
+}
+
+// detach marks e, which has been unlinked from the heap, as removed.
+func (h *PairingHeap[T]) detach(e *Element[T]) {
+	e.child, e.sibling, e.prev = nil, nil, nil
+	e.inHeap = false
+	h.len--
+}
+
+// meld links the trees rooted at a and b, which have no siblings, and
+// returns the root of the result.
+func (h *PairingHeap[T]) meld(a, b *Element[T]) *Element[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.comparator(b.value, a.value) > 0 {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// cut unlinks the subtree rooted at e from its parent and siblings.
+func (h *PairingHeap[T]) cut(e *Element[T]) {
+	if e.prev.child == e {
+		e.prev.child = e.sibling
+	} else {
+		e.prev.sibling = e.sibling
+	}
+	if e.sibling != nil {
+		e.sibling.prev = e.prev
+	}
+	e.prev, e.sibling = nil, nil
+}
+
+// mergePairs melds the list of siblings starting at first with the
+// standard two-pass method and returns the root of the result.
+func (h *PairingHeap[T]) mergePairs(first *Element[T]) *Element[T] {
+	var pairs []*Element[T]
+	for first != nil {
+		a, b := first, first.sibling
+		if b == nil {
+			a.prev = nil
+			pairs = append(pairs, a)
+			break
+		}
+		first = b.sibling
+		a.prev, a.sibling, b.prev, b.sibling = nil, nil, nil, nil
+		pairs = append(pairs, h.meld(a, b))
+	}
+	var root *Element[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.meld(pairs[i], root)
+	}
+	return root
+}
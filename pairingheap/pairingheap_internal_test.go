@@ -0,0 +1,76 @@
+package pairingheap
+
+import (
+	"cmp"
+	"testing"
+	"testing/quick"
+)
+
+// isValid reports whether the tree of h is heap-ordered, its links are
+// consistent and it holds h.len elements.
+func (h *PairingHeap[T]) isValid() bool {
+	if h.root == nil {
+		return h.len == 0
+	}
+	if h.root.prev != nil || h.root.sibling != nil {
+		return false
+	}
+	n := 0
+	stack := []*Element[T]{h.root}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n++
+		if !e.inHeap {
+			return false
+		}
+		prev := e
+		for c := e.child; c != nil; prev, c = c, c.sibling {
+			if c.prev != prev || h.comparator(c.value, e.value) > 0 {
+				return false
+			}
+			stack = append(stack, c)
+		}
+	}
+	return n == h.len
+}
+
+func TestHeapInvariant(t *testing.T) {
+	prop := func(ops []int8) bool {
+		h := New(cmp.Compare[int8])
+		var elems []*Element[int8]
+		for i, op := range ops {
+			switch {
+			case op < 0 && op%3 == 0:
+				if e := h.TopElement(); e != nil {
+					h.Pop()
+					elems = remove(elems, e)
+				}
+			case op < 0 && op%3 == -1 && len(elems) > 0:
+				e := elems[i%len(elems)]
+				h.Remove(e)
+				elems = remove(elems, e)
+			case op%2 == 1 && len(elems) > 0:
+				h.Update(elems[i%len(elems)], op)
+			default:
+				elems = append(elems, h.Insert(op))
+			}
+			if !h.isValid() {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(prop, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func remove[T any](elems []*Element[T], e *Element[T]) []*Element[T] {
+	for i, x := range elems {
+		if x == e {
+			return append(elems[:i], elems[i+1:]...)
+		}
+	}
+	return elems
+}
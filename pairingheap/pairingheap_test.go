@@ -0,0 +1,44 @@
+package pairingheap_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/pairingheap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollect(t *testing.T) {
+	h := pairingheap.Collect(slices.Values([]int{4, 9, 2, 7}), comparator.Reverse(cmp.Compare[int]))
+	assert.Equal(t, 4, h.Len())
+	v, _ := h.Top()
+	assert.Equal(t, 2, v)
+}
+
+func TestStaleElementPanics(t *testing.T) {
+	h := pairingheap.New(cmp.Compare[int])
+	e := h.Insert(1)
+	h.Pop()
+	assert.PanicsWithValue(t, "pairingheap.Update: element not in heap", func() {
+		h.Update(e, 2)
+	})
+	assert.PanicsWithValue(t, "pairingheap.Remove: element not in heap", func() {
+		h.Remove(e)
+	})
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestPriorityQueue(t, pairingheap.New[int])
+	containertest.TestAddressablePriorityQueue[*pairingheap.Element[int]](t, pairingheap.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestPriorityQueueModel(t, pairingheap.New[int])
+}
+
+func FuzzPairingHeap(f *testing.F) {
+	containertest.FuzzPriorityQueue(f, pairingheap.New[int])
+}
@@ -0,0 +1,512 @@
+// Package hashmap provides a persistent (immutable) hash map
+// implementation.
+//
+// A [HashMap] is never modified in place: Insert and Remove return a new
+// version that shares most of its structure with the old one. Versions
+// can therefore be handed out to many goroutines without copying or
+// locking.
+//
+// The map is a hash array mapped trie (HAMT): a 32-way trie indexed by
+// successive 5-bit chunks of the key hashes, whose nodes store only their
+// occupied slots. Lookups and updates take O(log32 n) time.
+package hashmap
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+
+	seqhashmap "github.com/linhns/gocontainers/hashmap"
+)
+
+const (
+	chunk = 5
+	mask  = 1<<chunk - 1
+)
+
+var seed = maphash.MakeSeed()
+
+func hashOf[K comparable](key K) uint64 {
+	return maphash.Comparable(seed, key)
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// slot is an occupied slot of a node. It holds either a child node or the
+// entries whose keys all have the same hash.
+type slot[K comparable, V any] struct {
+	child   *node[K, V]
+	hash    uint64
+	entries []entry[K, V]
+}
+
+// node is a node of the trie. Bit i of bitmap is set if the slot for the
+// 5-bit chunk i is occupied, and slots holds the occupied slots in order.
+//
+// Nodes are never modified once reachable from a map. Their shape only
+// depends on the set of keys, which allows comparing maps structurally.
+type node[K comparable, V any] struct {
+	bitmap uint32
+	slots  []slot[K, V]
+}
+
+// HashMap is a persistent hash map. The zero value is an empty map ready
+// to use.
+type HashMap[K comparable, V any] struct {
+	root *node[K, V]
+	len  int
+}
+
+// New returns an empty [HashMap].
+func New[K comparable, V any]() *HashMap[K, V] {
+	return &HashMap[K, V]{}
+}
+
+// Collect collects key-value pairs from an iterator and returns a new map.
+func Collect[K comparable, V any](seq iter.Seq2[K, V]) *HashMap[K, V] {
+	m := New[K, V]()
+	for k, v := range seq {
+		m = m.Insert(k, v)
+	}
+	return m
+}
+
+// FromHashMap returns a persistent copy of m.
+func FromHashMap[K comparable, V any](m *seqhashmap.HashMap[K, V]) *HashMap[K, V] {
+	return Collect(m.All())
+}
+
+// ToHashMap returns a copy of the key-value pairs of m as a mutable map.
+func (m *HashMap[K, V]) ToHashMap() *seqhashmap.HashMap[K, V] {
+	return seqhashmap.Collect(m.All())
+}
+
+// Len returns the number of key-value pairs in the map.
+func (m *HashMap[K, V]) Len() int {
+	return m.len
+}
+
+// Empty reports whether the map is empty.
+func (m *HashMap[K, V]) Empty() bool {
+	return m.len == 0
+}
+
+// Get retrieves the value associated with the key. If the key does not exist,
+// it returns the zero value of the value type and false.
+func (m *HashMap[K, V]) Get(key K) (V, bool) {
+	return m.root.get(hashOf(key), 0, key)
+}
+
+// Contains reports whether the map contains the key.
+func (m *HashMap[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Insert returns a new map with the key associated with value.
+//
+// If m already contains the key, its value is replaced in the new map.
+func (m *HashMap[K, V]) Insert(key K, value V) *HashMap[K, V] {
+	return m.insert(hashOf(key), key, value)
+}
+
+func (m *HashMap[K, V]) insert(hash uint64, key K, value V) *HashMap[K, V] {
+	root, added := m.root.insert(hash, 0, key, value)
+	n := m.len
+	if added {
+		n++
+	}
+	return &HashMap[K, V]{root: root, len: n}
+}
+
+// Remove returns a new map without the key. If m does not contain the
+// key, Remove returns m.
+func (m *HashMap[K, V]) Remove(key K) *HashMap[K, V] {
+	return m.remove(hashOf(key), key)
+}
+
+func (m *HashMap[K, V]) remove(hash uint64, key K) *HashMap[K, V] {
+	root, removed := m.root.remove(hash, 0, key)
+	if !removed {
+		return m
+	}
+	if len(root.slots) == 0 {
+		root = nil
+	}
+	return &HashMap[K, V]{root: root, len: m.len - 1}
+}
+
+// Keys returns an iterator over keys in the map.
+// The iteration order is unspecified but the same for equal maps.
+func (m *HashMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over values in the map.
+// The iteration order is unspecified but the same for equal maps.
+func (m *HashMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over key-value pairs in the map.
+// The iteration order is unspecified but the same for equal maps.
+func (m *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.each(func(_ uint64, k K, v V) bool {
+			return yield(k, v)
+		})
+	}
+}
+
+// Equal reports whether two maps contain the same key-value pairs.
+//
+// Subtrees shared by both maps are not visited, so comparing a map with
+// a version derived from it by a few updates is fast.
+func Equal[K, V comparable](m1, m2 *HashMap[K, V]) bool {
+	return EqualFunc(m1, m2, func(v1, v2 V) bool { return v1 == v2 })
+}
+
+// EqualFunc is like [Equal], but compares values using eq.
+func EqualFunc[K comparable, V1, V2 any](m1 *HashMap[K, V1], m2 *HashMap[K, V2], eq func(V1, V2) bool) bool {
+	return m1.len == m2.len && equalNodes(m1.root, m2.root, eq)
+}
+
+// ChangeKind is the kind of a [Change].
+type ChangeKind int
+
+const (
+	// Added means the key is only in the new map.
+	Added ChangeKind = iota
+	// Removed means the key is only in the old map.
+	Removed
+	// Modified means the key is in both maps with different values.
+	Modified
+)
+
+// String returns the name of the change kind.
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "Added"
+	case Removed:
+		return "Removed"
+	case Modified:
+		return "Modified"
+	}
+	return "ChangeKind(?)"
+}
+
+// Change describes a difference between two maps. Old is the zero value
+// for an added key and New the zero value for a removed key.
+type Change[K comparable, V any] struct {
+	Kind ChangeKind
+	Key  K
+	Old  V
+	New  V
+}
+
+// Diff returns an iterator over the changes that turn from into to.
+//
+// Like [Equal], Diff skips the subtrees shared by both maps, so its cost
+// is proportional to the number of changes when to is derived from
+// from, or vice versa.
+func Diff[K, V comparable](from, to *HashMap[K, V]) iter.Seq[Change[K, V]] {
+	return DiffFunc(from, to, func(v1, v2 V) bool { return v1 == v2 })
+}
+
+// DiffFunc is like [Diff], but compares values using eq.
+func DiffFunc[K comparable, V any](from, to *HashMap[K, V], eq func(V, V) bool) iter.Seq[Change[K, V]] {
+	return func(yield func(Change[K, V]) bool) {
+		diffNodes(from.root, to.root, 0, eq, yield)
+	}
+}
+
+func (n *node[K, V]) index(hash uint64, shift uint) (bit uint32, pos int) {
+	bit = 1 << ((hash >> shift) & mask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *node[K, V]) get(hash uint64, shift uint, key K) (value V, ok bool) {
+	for n != nil {
+		bit, pos := n.index(hash, shift)
+		if n.bitmap&bit == 0 {
+			return
+		}
+		s := &n.slots[pos]
+		if s.child == nil {
+			return s.lookup(hash, key)
+		}
+		n = s.child
+		shift += chunk
+	}
+	return
+}
+
+// lookup looks up the key in the entries of a slot without a child.
+func (s *slot[K, V]) lookup(hash uint64, key K) (value V, ok bool) {
+	if s.hash != hash {
+		return
+	}
+	for _, e := range s.entries {
+		if e.key == key {
+			return e.value, true
+		}
+	}
+	return
+}
+
+// find looks up the key in the subtree of a slot of a node at shift.
+func (s *slot[K, V]) find(hash uint64, shift uint, key K) (V, bool) {
+	if s.child != nil {
+		return s.child.get(hash, shift+chunk, key)
+	}
+	return s.lookup(hash, key)
+}
+
+func (n *node[K, V]) insert(hash uint64, shift uint, key K, value V) (*node[K, V], bool) {
+	if n == nil {
+		n = &node[K, V]{}
+	}
+	bit, pos := n.index(hash, shift)
+	if n.bitmap&bit == 0 {
+		ret := &node[K, V]{bitmap: n.bitmap | bit, slots: make([]slot[K, V], len(n.slots)+1)}
+		copy(ret.slots, n.slots[:pos])
+		ret.slots[pos] = slot[K, V]{hash: hash, entries: []entry[K, V]{{key, value}}}
+		copy(ret.slots[pos+1:], n.slots[pos:])
+		return ret, true
+	}
+
+	s := n.slots[pos]
+	added := true
+	switch {
+	case s.child != nil:
+		s.child, added = s.child.insert(hash, shift+chunk, key, value)
+	case s.hash == hash:
+		i := 0
+		for i < len(s.entries) && s.entries[i].key != key {
+			i++
+		}
+		entries := make([]entry[K, V], max(len(s.entries), i+1))
+		copy(entries, s.entries)
+		entries[i] = entry[K, V]{key, value}
+		added = i == len(s.entries)
+		s.entries = entries
+	default:
+		s = slot[K, V]{child: merge(s, slot[K, V]{hash: hash, entries: []entry[K, V]{{key, value}}}, shift+chunk)}
+	}
+	return n.with(pos, s), added
+}
+
+// merge returns a node at shift holding the slots a and b, whose hashes
+// differ.
+func merge[K comparable, V any](a, b slot[K, V], shift uint) *node[K, V] {
+	ia, ib := (a.hash>>shift)&mask, (b.hash>>shift)&mask
+	if ia == ib {
+		return &node[K, V]{
+			bitmap: 1 << ia,
+			slots:  []slot[K, V]{{child: merge(a, b, shift+chunk)}},
+		}
+	}
+	if ia > ib {
+		a, b = b, a
+	}
+	return &node[K, V]{
+		bitmap: 1<<ia | 1<<ib,
+		slots:  []slot[K, V]{a, b},
+	}
+}
+
+// with returns a copy of n with the slot at pos replaced by s.
+func (n *node[K, V]) with(pos int, s slot[K, V]) *node[K, V] {
+	ret := &node[K, V]{bitmap: n.bitmap, slots: make([]slot[K, V], len(n.slots))}
+	copy(ret.slots, n.slots)
+	ret.slots[pos] = s
+	return ret
+}
+
+// without returns a copy of n without the slot at pos for bit.
+func (n *node[K, V]) without(bit uint32, pos int) *node[K, V] {
+	ret := &node[K, V]{bitmap: n.bitmap &^ bit, slots: make([]slot[K, V], 0, len(n.slots)-1)}
+	ret.slots = append(ret.slots, n.slots[:pos]...)
+	ret.slots = append(ret.slots, n.slots[pos+1:]...)
+	return ret
+}
+
+func (n *node[K, V]) remove(hash uint64, shift uint, key K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	bit, pos := n.index(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	s := n.slots[pos]
+	if s.child != nil {
+		child, removed := s.child.remove(hash, shift+chunk, key)
+		if !removed {
+			return n, false
+		}
+		switch {
+		case len(child.slots) == 0:
+			return n.without(bit, pos), true
+		case len(child.slots) == 1 && child.slots[0].child == nil:
+			// Keep the trie canonical by pulling up a lone bucket.
+			return n.with(pos, child.slots[0]), true
+		}
+		s.child = child
+		return n.with(pos, s), true
+	}
+
+	if s.hash != hash {
+		return n, false
+	}
+	for i, e := range s.entries {
+		if e.key != key {
+			continue
+		}
+		if len(s.entries) == 1 {
+			return n.without(bit, pos), true
+		}
+		entries := make([]entry[K, V], 0, len(s.entries)-1)
+		entries = append(entries, s.entries[:i]...)
+		s.entries = append(entries, s.entries[i+1:]...)
+		return n.with(pos, s), true
+	}
+	return n, false
+}
+
+func (n *node[K, V]) each(yield func(uint64, K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for i := range n.slots {
+		if !n.slots[i].each(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// each calls yield with the hash, key and value of every entry in the
+// subtree of s until yield returns false.
+func (s *slot[K, V]) each(yield func(uint64, K, V) bool) bool {
+	if s.child != nil {
+		return s.child.each(yield)
+	}
+	for _, e := range s.entries {
+		if !yield(s.hash, e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalNodes[K comparable, V1, V2 any](a *node[K, V1], b *node[K, V2], eq func(V1, V2) bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if any(a) == any(b) {
+		return true
+	}
+	if a.bitmap != b.bitmap {
+		return false
+	}
+	for i := range a.slots {
+		sa, sb := &a.slots[i], &b.slots[i]
+		if (sa.child == nil) != (sb.child == nil) {
+			return false
+		}
+		if sa.child != nil {
+			if !equalNodes(sa.child, sb.child, eq) {
+				return false
+			}
+			continue
+		}
+		if sa.hash != sb.hash || len(sa.entries) != len(sb.entries) {
+			return false
+		}
+		for _, e := range sa.entries {
+			v, ok := sb.lookup(sa.hash, e.key)
+			if !ok || !eq(e.value, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// diffNodes yields the changes between the nodes at shift, returning false
+// if yield asked to stop.
+func diffNodes[K comparable, V any](a, b *node[K, V], shift uint, eq func(V, V) bool, yield func(Change[K, V]) bool) bool {
+	if a == b {
+		return true
+	}
+	if a == nil {
+		a = &node[K, V]{}
+	}
+	if b == nil {
+		b = &node[K, V]{}
+	}
+	for bitmap := a.bitmap | b.bitmap; bitmap != 0; bitmap &= bitmap - 1 {
+		bit := bitmap & -bitmap
+		pa := bits.OnesCount32(a.bitmap & (bit - 1))
+		pb := bits.OnesCount32(b.bitmap & (bit - 1))
+		var ok bool
+		switch {
+		case a.bitmap&bit == 0:
+			ok = b.slots[pb].each(func(_ uint64, k K, v V) bool {
+				return yield(Change[K, V]{Kind: Added, Key: k, New: v})
+			})
+		case b.bitmap&bit == 0:
+			ok = a.slots[pa].each(func(_ uint64, k K, v V) bool {
+				return yield(Change[K, V]{Kind: Removed, Key: k, Old: v})
+			})
+		default:
+			ok = diffSlots(&a.slots[pa], &b.slots[pb], shift, eq, yield)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func diffSlots[K comparable, V any](sa, sb *slot[K, V], shift uint, eq func(V, V) bool, yield func(Change[K, V]) bool) bool {
+	if sa.child != nil && sb.child != nil {
+		return diffNodes(sa.child, sb.child, shift+chunk, eq, yield)
+	}
+
+	// At least one side is a single bucket, so look up keys one by one.
+	ok := sa.each(func(hash uint64, k K, old V) bool {
+		v, found := sb.find(hash, shift, k)
+		switch {
+		case !found:
+			return yield(Change[K, V]{Kind: Removed, Key: k, Old: old})
+		case !eq(old, v):
+			return yield(Change[K, V]{Kind: Modified, Key: k, Old: old, New: v})
+		}
+		return true
+	})
+	return ok && sb.each(func(hash uint64, k K, v V) bool {
+		if _, found := sa.find(hash, shift, k); !found {
+			return yield(Change[K, V]{Kind: Added, Key: k, New: v})
+		}
+		return true
+	})
+}
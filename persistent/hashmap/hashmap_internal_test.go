@@ -0,0 +1,100 @@
+package hashmap
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The hashes below are chosen by hand to exercise collisions that are
+// practically impossible to produce with maphash.
+
+func TestFullHashCollision(t *testing.T) {
+	t.Parallel()
+
+	m := New[string, int]().insert(7, "a", 1).insert(7, "b", 2).insert(7, "c", 3)
+	assert.Equal(t, 3, m.Len())
+	assert.Len(t, m.root.slots, 1)
+	assert.Len(t, m.root.slots[0].entries, 3)
+
+	v, ok := m.root.get(7, 0, "b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	m2 := m.remove(7, "b")
+	assert.Equal(t, 2, m2.Len())
+	_, ok = m2.root.get(7, 0, "b")
+	assert.False(t, ok)
+	_, ok = m.root.get(7, 0, "b")
+	assert.True(t, ok)
+	assert.Same(t, m2, m2.remove(7, "b"))
+	assert.Same(t, m2, m2.remove(8, "a"))
+
+	other := New[string, int]().insert(7, "c", 3).insert(7, "a", 1)
+	assert.True(t, Equal(m2, other))
+	assert.Empty(t, slices.Collect(Diff(m2, other)))
+	assert.Nil(t, m2.remove(7, "a").remove(7, "c").root)
+}
+
+func TestPartialHashCollision(t *testing.T) {
+	t.Parallel()
+
+	// a and b share the lowest 60 bits, and c shares the lowest 10.
+	const (
+		a uint64 = 1
+		b uint64 = 1<<60 | 1
+		c uint64 = 1<<10 | 1
+	)
+	m := New[string, int]().insert(a, "a", 1).insert(b, "b", 2)
+	depth := 0
+	for n := m.root; n != nil; n = n.slots[0].child {
+		depth++
+	}
+	assert.Equal(t, 13, depth)
+
+	m2 := m.insert(c, "c", 3)
+	for h, k := range map[uint64]string{a: "a", b: "b", c: "c"} {
+		_, ok := m2.root.get(h, 0, k)
+		assert.True(t, ok)
+	}
+
+	// Removing a key collapses the nodes that only held the other one,
+	// so the trie has the same shape as if it had never been added.
+	m3 := m2.remove(b, "b")
+	assert.Equal(t, New[string, int]().insert(c, "c", 3).insert(a, "a", 1).root, m3.root)
+	assert.True(t, Equal(m3, New[string, int]().insert(a, "a", 1).insert(c, "c", 3)))
+
+	assert.Equal(t, []Change[string, int]{{Kind: Removed, Key: "b", Old: 2}}, slices.Collect(Diff(m2, m3)))
+}
+
+func TestCanonical(t *testing.T) {
+	t.Parallel()
+
+	// Use hashes with few distinct bits to get many deep paths and
+	// collisions, and check that the shape of the trie only depends on
+	// the keys it holds.
+	r := rand.New(rand.NewPCG(5, 6))
+	hash := func(k int) uint64 { return uint64(k%7)<<55 | uint64(k%5)<<5 | uint64(k%3) }
+	m := New[int, int]()
+	model := make(map[int]int)
+	for range 5000 {
+		k := r.IntN(200)
+		if r.IntN(2) == 0 {
+			m = m.remove(hash(k), k)
+			delete(model, k)
+		} else {
+			m = m.insert(hash(k), k, k)
+			model[k] = k
+		}
+	}
+	fresh := New[int, int]()
+	for k := range model {
+		fresh = fresh.insert(hash(k), k, k)
+	}
+	assert.Equal(t, model, maps.Collect(m.All()))
+	assert.True(t, Equal(m, fresh))
+	assert.Empty(t, slices.Collect(Diff(m, fresh)))
+}
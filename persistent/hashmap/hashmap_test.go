@@ -0,0 +1,191 @@
+package hashmap_test
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	seqhashmap "github.com/linhns/gocontainers/hashmap"
+	"github.com/linhns/gocontainers/persistent/hashmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashMapBasics(t *testing.T) {
+	t.Parallel()
+
+	var m hashmap.HashMap[string, int]
+	assert.True(t, m.Empty())
+	assert.Same(t, &m, m.Remove("a"))
+
+	m1 := m.Insert("a", 1)
+	m2 := m1.Insert("b", 2)
+	m3 := m2.Insert("a", 10)
+	assert.Equal(t, 1, m1.Len())
+	assert.Equal(t, 2, m2.Len())
+	assert.Equal(t, 2, m3.Len())
+
+	v, ok := m2.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	v, ok = m3.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	_, ok = m1.Get("b")
+	assert.False(t, ok)
+	assert.True(t, m2.Contains("b"))
+	assert.False(t, m2.Contains("c"))
+
+	m4 := m3.Remove("a")
+	assert.Equal(t, 1, m4.Len())
+	assert.False(t, m4.Contains("a"))
+	assert.True(t, m3.Contains("a"))
+
+	assert.Equal(t, map[string]int{"a": 10, "b": 2}, maps.Collect(m3.All()))
+	assert.ElementsMatch(t, []string{"a", "b"}, slices.Collect(m3.Keys()))
+	assert.ElementsMatch(t, []int{10, 2}, slices.Collect(m3.Values()))
+}
+
+func TestHashMapModel(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(1, 2))
+	m := hashmap.New[int, int]()
+	model := make(map[int]int)
+	type version struct {
+		m     *hashmap.HashMap[int, int]
+		model map[int]int
+	}
+	var versions []version
+	for i := range 20000 {
+		k := r.IntN(2000)
+		if r.IntN(3) == 0 {
+			m = m.Remove(k)
+			delete(model, k)
+		} else {
+			m = m.Insert(k, i)
+			model[k] = i
+		}
+		assert.Equal(t, len(model), m.Len())
+		if i%1000 == 0 {
+			versions = append(versions, version{m, maps.Clone(model)})
+		}
+	}
+	for _, v := range versions {
+		assert.Equal(t, v.model, maps.Collect(v.m.All()))
+	}
+	for k := range 2000 {
+		want, wantOK := model[k]
+		got, gotOK := m.Get(k)
+		assert.Equal(t, wantOK, gotOK)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	m1 := hashmap.New[int, string]()
+	m2 := hashmap.New[int, string]()
+	assert.True(t, hashmap.Equal(m1, m2))
+
+	for i := range 1000 {
+		m1 = m1.Insert(i, "x")
+	}
+	for i := 999; i >= 0; i-- {
+		m2 = m2.Insert(i, "x")
+	}
+	assert.True(t, hashmap.Equal(m1, m2))
+	assert.True(t, hashmap.Equal(m1, m1))
+
+	assert.False(t, hashmap.Equal(m1, m2.Insert(5, "y")))
+	assert.False(t, hashmap.Equal(m1, m2.Remove(5)))
+	assert.False(t, hashmap.Equal(m1.Remove(4), m2.Remove(5)))
+	assert.True(t, hashmap.Equal(m1.Remove(5), m2.Remove(5)))
+	assert.True(t, hashmap.Equal(m1.Insert(5000, "z").Remove(5000), m2))
+
+	lengths := m1.Insert(5, "yyy")
+	assert.True(t, hashmap.EqualFunc(m1.Insert(5, "zzz"), lengths, func(a, b string) bool {
+		return len(a) == len(b)
+	}))
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	from := hashmap.New[int, int]()
+	for i := range 5000 {
+		from = from.Insert(i, i)
+	}
+	to := from.Insert(1, -1).Insert(5000, 5000).Remove(2).Insert(3, 3)
+
+	changes := slices.Collect(hashmap.Diff(from, to))
+	assert.ElementsMatch(t, []hashmap.Change[int, int]{
+		{Kind: hashmap.Modified, Key: 1, Old: 1, New: -1},
+		{Kind: hashmap.Added, Key: 5000, New: 5000},
+		{Kind: hashmap.Removed, Key: 2, Old: 2},
+	}, changes)
+
+	assert.Empty(t, slices.Collect(hashmap.Diff(from, from)))
+	assert.Len(t, slices.Collect(hashmap.Diff(hashmap.New[int, int](), from)), 5000)
+	assert.Len(t, slices.Collect(hashmap.Diff(from, hashmap.New[int, int]())), 5000)
+
+	assert.Contains(t, slices.Collect(hashmap.Diff(to, from)), hashmap.Change[int, int]{
+		Kind: hashmap.Added, Key: 2, New: 2,
+	})
+	assert.Equal(t, "Modified", hashmap.Modified.String())
+
+	n := 0
+	for range hashmap.Diff(hashmap.New[int, int](), from) {
+		n++
+		break
+	}
+	assert.Equal(t, 1, n)
+}
+
+func TestDiffModel(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(3, 4))
+	from := hashmap.New[int, int]()
+	for range 3000 {
+		from = from.Insert(r.IntN(5000), r.IntN(3))
+	}
+	to := from
+	for range 300 {
+		if k := r.IntN(5000); r.IntN(2) == 0 {
+			to = to.Remove(k)
+		} else {
+			to = to.Insert(k, r.IntN(3))
+		}
+	}
+
+	// Replaying the diff onto from must give to.
+	got := maps.Collect(from.All())
+	for c := range hashmap.Diff(from, to) {
+		switch c.Kind {
+		case hashmap.Added, hashmap.Modified:
+			got[c.Key] = c.New
+		case hashmap.Removed:
+			delete(got, c.Key)
+		}
+	}
+	assert.Equal(t, maps.Collect(to.All()), got)
+}
+
+func TestConversion(t *testing.T) {
+	t.Parallel()
+
+	s := seqhashmap.New[string, int]()
+	s.Insert("a", 1)
+	s.Insert("b", 2)
+	m := hashmap.FromHashMap(s)
+	s.Insert("c", 3)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, maps.Collect(m.All()))
+
+	back := m.Insert("d", 4).ToHashMap()
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "d": 4}, maps.Collect(back.All()))
+
+	c := hashmap.Collect(maps.All(map[int]int{1: 1, 2: 4}))
+	assert.Equal(t, 2, c.Len())
+}
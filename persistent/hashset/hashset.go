@@ -0,0 +1,115 @@
+// Package hashset provides a persistent (immutable) set based on a hash
+// array mapped trie.
+//
+// A [HashSet] is never modified in place: Add and Remove return a new
+// version that shares most of its structure with the old one.
+package hashset
+
+import (
+	"iter"
+
+	seqhashset "github.com/linhns/gocontainers/hashset"
+	"github.com/linhns/gocontainers/persistent/hashmap"
+)
+
+// HashSet is a persistent set of unique elements. The zero value is an
+// empty set ready to use.
+type HashSet[K comparable] struct {
+	m hashmap.HashMap[K, struct{}]
+}
+
+// New returns an empty [HashSet].
+func New[K comparable]() *HashSet[K] {
+	return &HashSet[K]{}
+}
+
+// Of returns a [HashSet] holding vals.
+func Of[K comparable](vals ...K) *HashSet[K] {
+	s := New[K]()
+	for _, v := range vals {
+		s = s.Add(v)
+	}
+	return s
+}
+
+// Collect collects elements from an iterator and returns a new set.
+func Collect[K comparable](seq iter.Seq[K]) *HashSet[K] {
+	s := New[K]()
+	for v := range seq {
+		s = s.Add(v)
+	}
+	return s
+}
+
+// FromHashSet returns a persistent copy of s.
+func FromHashSet[K comparable](s *seqhashset.HashSet[K]) *HashSet[K] {
+	return Collect(s.All())
+}
+
+// ToHashSet returns a copy of the elements of s as a mutable set.
+func (s *HashSet[K]) ToHashSet() *seqhashset.HashSet[K] {
+	return seqhashset.Collect(s.All())
+}
+
+func wrap[K comparable](m *hashmap.HashMap[K, struct{}]) *HashSet[K] {
+	return &HashSet[K]{m: *m}
+}
+
+// Len returns the number of elements in the set.
+func (s *HashSet[K]) Len() int {
+	return s.m.Len()
+}
+
+// Empty reports whether the set is empty.
+func (s *HashSet[K]) Empty() bool {
+	return s.m.Empty()
+}
+
+// Contains reports whether an element exists in the set.
+func (s *HashSet[K]) Contains(key K) bool {
+	return s.m.Contains(key)
+}
+
+// Add returns a new set with key added.
+func (s *HashSet[K]) Add(key K) *HashSet[K] {
+	return wrap(s.m.Insert(key, struct{}{}))
+}
+
+// Remove returns a new set without key. If s does not contain key,
+// Remove returns s.
+func (s *HashSet[K]) Remove(key K) *HashSet[K] {
+	m := s.m.Remove(key)
+	if m == &s.m {
+		return s
+	}
+	return wrap(m)
+}
+
+// All is an iterator over the elements in the set.
+// The iteration order is unspecified but the same for equal sets.
+func (s *HashSet[K]) All() iter.Seq[K] {
+	return s.m.Keys()
+}
+
+// Equal reports whether two sets contain the same elements.
+//
+// Subtrees shared by both sets are not visited, so comparing a set with
+// a version derived from it by a few updates is fast.
+func Equal[K comparable](s1, s2 *HashSet[K]) bool {
+	return hashmap.Equal(&s1.m, &s2.m)
+}
+
+// Diff returns an iterator over the elements that differ between from
+// and to. It yields true for the elements only in to, which were added,
+// and false for the elements only in from, which were removed.
+//
+// Like [Equal], Diff skips the subtrees shared by both sets.
+func Diff[K comparable](from, to *HashSet[K]) iter.Seq2[K, bool] {
+	return func(yield func(K, bool) bool) {
+		for c := range hashmap.Diff(&from.m, &to.m) {
+			if !yield(c.Key, c.Kind == hashmap.Added) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package hashset_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	seqhashset "github.com/linhns/gocontainers/hashset"
+	"github.com/linhns/gocontainers/persistent/hashset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashSetBasics(t *testing.T) {
+	t.Parallel()
+
+	var s hashset.HashSet[int]
+	assert.True(t, s.Empty())
+	assert.Same(t, &s, s.Remove(1))
+
+	s1 := s.Add(1).Add(2).Add(2)
+	s2 := s1.Remove(1)
+	assert.Equal(t, 2, s1.Len())
+	assert.Equal(t, 1, s2.Len())
+	assert.True(t, s1.Contains(1))
+	assert.False(t, s2.Contains(1))
+	assert.Same(t, s2, s2.Remove(1))
+	assert.ElementsMatch(t, []int{1, 2}, slices.Collect(s1.All()))
+}
+
+func TestEqualAndDiff(t *testing.T) {
+	t.Parallel()
+
+	s1 := hashset.Collect(slices.Values([]int{1, 2, 3, 4}))
+	s2 := hashset.Of(4, 3, 2, 1)
+	assert.True(t, hashset.Equal(s1, s2))
+	assert.False(t, hashset.Equal(s1, s2.Remove(3)))
+
+	s3 := s1.Remove(1).Add(5)
+	assert.Equal(t, map[int]bool{1: false, 5: true}, maps.Collect(hashset.Diff(s1, s3)))
+	assert.Empty(t, maps.Collect(hashset.Diff(s1, s2)))
+}
+
+func TestConversion(t *testing.T) {
+	t.Parallel()
+
+	h := seqhashset.New[string]()
+	h.Add("a")
+	s := hashset.FromHashSet(h)
+	h.Add("b")
+	assert.Equal(t, 1, s.Len())
+
+	back := s.Add("c").ToHashSet()
+	assert.True(t, seqhashset.Equal(back, seqhashset.Collect(slices.Values([]string{"a", "c"}))))
+}
@@ -0,0 +1,444 @@
+// Package vector provides a persistent (immutable) vector implementation.
+//
+// A [Vector] is never modified in place: Append, Set and Pop return a new
+// version that shares most of its structure with the old one. Versions
+// can therefore be handed out to many goroutines without copying or
+// locking.
+//
+// The vector is a 32-way bitmapped trie, as in Clojure's
+// PersistentVector, so updates and lookups take O(log32 n) time,
+// which is effectively constant.
+package vector
+
+import (
+	"iter"
+
+	seqvector "github.com/linhns/gocontainers/vector"
+)
+
+const (
+	bits  = 5
+	width = 1 << bits
+	mask  = width - 1
+)
+
+// owner identifies the transient allowed to modify a node in place.
+// It is not zero-sized so that distinct owners have distinct addresses.
+type owner struct {
+	_ byte
+}
+
+// node is a node of the trie. Internal nodes hold children and leaves
+// hold values, both of length width.
+type node[T any] struct {
+	edit     *owner
+	children []*node[T]
+	values   []T
+}
+
+// Vector is a persistent vector. The zero value is an empty vector
+// ready to use.
+type Vector[T any] struct {
+	len   int
+	shift uint
+	root  *node[T]
+	// tail holds the last 1 to 32 elements, which are not stored in the
+	// trie, so that appending is usually a copy of the tail only.
+	tail []T
+}
+
+// New returns an empty [Vector].
+func New[T any]() *Vector[T] {
+	return &Vector[T]{shift: bits}
+}
+
+// Of returns a [Vector] holding vals.
+func Of[T any](vals ...T) *Vector[T] {
+	t := New[T]().Transient()
+	for _, v := range vals {
+		t.Append(v)
+	}
+	return t.Persistent()
+}
+
+// Collect collects values from an iterator and returns a new vector.
+func Collect[T any](seq iter.Seq[T]) *Vector[T] {
+	t := New[T]().Transient()
+	for v := range seq {
+		t.Append(v)
+	}
+	return t.Persistent()
+}
+
+// FromVector returns a persistent copy of v.
+func FromVector[T any](v *seqvector.Vector[T]) *Vector[T] {
+	return Collect(v.Values())
+}
+
+// ToVector returns a copy of the elements of v as a mutable vector.
+func (v *Vector[T]) ToVector() *seqvector.Vector[T] {
+	s := seqvector.NewWithCapacity[T](v.len)
+	for x := range v.Values() {
+		s.PushBack(x)
+	}
+	return s
+}
+
+// Len returns the number of elements in the vector.
+func (v *Vector[T]) Len() int {
+	return v.len
+}
+
+// Empty reports whether the vector is empty.
+func (v *Vector[T]) Empty() bool {
+	return v.len == 0
+}
+
+// Get returns the zero-indexed ith element of v, if any.
+func (v *Vector[T]) Get(i int) (value T, ok bool) {
+	if i < 0 || i >= v.len {
+		return
+	}
+	return v.leafFor(i)[i&mask], true
+}
+
+// leafFor returns the values of the leaf, or the tail, holding the ith
+// element of v.
+func (v *Vector[T]) leafFor(i int) []T {
+	if i >= tailOffset(v.len) {
+		return v.tail
+	}
+	return leafFor(v.root, v.shift, i)
+}
+
+// Front returns the first element of v, if any.
+func (v *Vector[T]) Front() (T, bool) {
+	return v.Get(0)
+}
+
+// Back returns the last element of v, if any.
+func (v *Vector[T]) Back() (T, bool) {
+	return v.Get(v.len - 1)
+}
+
+// Append returns a new vector with val added to the end of v.
+func (v *Vector[T]) Append(val T) *Vector[T] {
+	if v.len-tailOffset(v.len) < width {
+		return &Vector[T]{
+			len:   v.len + 1,
+			shift: v.shift,
+			root:  v.root,
+			tail:  append(v.tail[:len(v.tail):len(v.tail)], val),
+		}
+	}
+
+	root, shift := pushTail(nil, v.root, max(v.shift, bits), v.len, &node[T]{values: v.tail})
+	return &Vector[T]{
+		len:   v.len + 1,
+		shift: shift,
+		root:  root,
+		tail:  []T{val},
+	}
+}
+
+// Set returns a new vector with the zero-indexed ith element of v
+// set to value.
+//
+// Set panics if i is negative or greater than or equal to the length of v.
+func (v *Vector[T]) Set(i int, value T) *Vector[T] {
+	if i < 0 || i >= v.len {
+		panic("vector.Set: index out of range")
+	}
+	if i >= tailOffset(v.len) {
+		tail := make([]T, len(v.tail))
+		copy(tail, v.tail)
+		tail[i&mask] = value
+		return &Vector[T]{len: v.len, shift: v.shift, root: v.root, tail: tail}
+	}
+	return &Vector[T]{
+		len:   v.len,
+		shift: v.shift,
+		root:  assoc(nil, v.root, v.shift, i, value),
+		tail:  v.tail,
+	}
+}
+
+// Pop returns a new vector with the last element of v removed.
+// If v is empty, Pop returns v.
+func (v *Vector[T]) Pop() *Vector[T] {
+	switch {
+	case v.len == 0:
+		return v
+	case v.len == 1:
+		return New[T]()
+	case v.len-tailOffset(v.len) > 1:
+		return &Vector[T]{
+			len:   v.len - 1,
+			shift: v.shift,
+			root:  v.root,
+			tail:  v.tail[: len(v.tail)-1 : len(v.tail)-1],
+		}
+	}
+
+	tail := leafFor(v.root, v.shift, v.len-2)
+	root, shift := popTail(nil, v.root, v.shift, v.len)
+	return &Vector[T]{len: v.len - 1, shift: shift, root: root, tail: tail}
+}
+
+// Values returns an iterator that yields the vector elements in order.
+func (v *Vector[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, x := range v.All() {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over index-value pairs in the vector.
+func (v *Vector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for base := 0; base < v.len; base += width {
+			leaf := v.leafFor(base)
+			for j, x := range leaf[:min(width, v.len-base)] {
+				if !yield(base+j, x) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Transient returns a transient copy of v for building a new version
+// with a batch of updates.
+//
+// The transient shares structure with v but modifies the nodes it owns in
+// place, which avoids most allocations of the corresponding persistent
+// operations. v itself is never affected.
+func (v *Vector[T]) Transient() *Transient[T] {
+	tail := make([]T, len(v.tail), width)
+	copy(tail, v.tail)
+	return &Transient[T]{
+		edit:  &owner{},
+		len:   v.len,
+		shift: max(v.shift, bits),
+		root:  v.root,
+		tail:  tail,
+	}
+}
+
+// Transient is a mutable view of a [Vector] used to build a new version
+// efficiently. It is not safe for concurrent use.
+//
+// A Transient must not be used after calling its Persistent method.
+type Transient[T any] struct {
+	edit  *owner
+	len   int
+	shift uint
+	root  *node[T]
+	tail  []T
+}
+
+func (t *Transient[T]) ensureEditable() {
+	if t.edit == nil {
+		panic("vector: transient used after Persistent")
+	}
+}
+
+// Persistent returns the vector built by t and invalidates t.
+func (t *Transient[T]) Persistent() *Vector[T] {
+	t.ensureEditable()
+	t.edit = nil
+	return &Vector[T]{len: t.len, shift: t.shift, root: t.root, tail: t.tail}
+}
+
+// Len returns the number of elements in the transient.
+func (t *Transient[T]) Len() int {
+	t.ensureEditable()
+	return t.len
+}
+
+// Get returns the zero-indexed ith element of t, if any.
+func (t *Transient[T]) Get(i int) (value T, ok bool) {
+	t.ensureEditable()
+	if i < 0 || i >= t.len {
+		return
+	}
+	if i >= tailOffset(t.len) {
+		return t.tail[i&mask], true
+	}
+	return leafFor(t.root, t.shift, i)[i&mask], true
+}
+
+// Append adds val to the end of t.
+func (t *Transient[T]) Append(val T) {
+	t.ensureEditable()
+	if t.len-tailOffset(t.len) < width {
+		t.tail = append(t.tail, val)
+		t.len++
+		return
+	}
+
+	t.root, t.shift = pushTail(t.edit, t.root, t.shift, t.len, &node[T]{edit: t.edit, values: t.tail})
+	t.tail = make([]T, 1, width)
+	t.tail[0] = val
+	t.len++
+}
+
+// Set sets the zero-indexed ith element of t to value.
+//
+// Set panics if i is negative or greater than or equal to the length of t.
+func (t *Transient[T]) Set(i int, value T) {
+	t.ensureEditable()
+	if i < 0 || i >= t.len {
+		panic("vector.Set: index out of range")
+	}
+	if i >= tailOffset(t.len) {
+		t.tail[i&mask] = value
+		return
+	}
+	t.root = assoc(t.edit, t.root, t.shift, i, value)
+}
+
+// Pop removes the last element of t. If t is empty, Pop is a no-op.
+func (t *Transient[T]) Pop() {
+	t.ensureEditable()
+	switch {
+	case t.len == 0:
+		return
+	case t.len == 1 || t.len-tailOffset(t.len) > 1:
+		var zero T
+		t.tail[len(t.tail)-1] = zero
+		t.tail = t.tail[:len(t.tail)-1]
+		t.len--
+		return
+	}
+
+	leaf := leafFor(t.root, t.shift, t.len-2)
+	t.tail = make([]T, width, width)
+	copy(t.tail, leaf)
+	t.root, t.shift = popTail(t.edit, t.root, t.shift, t.len)
+	t.len--
+}
+
+// tailOffset returns the index of the first element in the tail of
+// a vector of length n.
+func tailOffset(n int) int {
+	if n < width {
+		return 0
+	}
+	return ((n - 1) >> bits) << bits
+}
+
+// leafFor returns the values of the leaf of the trie holding the ith
+// element.
+func leafFor[T any](root *node[T], shift uint, i int) []T {
+	nd := root
+	for level := shift; level > 0; level -= bits {
+		nd = nd.children[(i>>level)&mask]
+	}
+	return nd.values
+}
+
+// editable returns a node that edit may modify in place: nd itself if it
+// is owned by edit, and a copy of nd otherwise. A nil nd yields a new
+// internal node.
+func editable[T any](edit *owner, nd *node[T]) *node[T] {
+	if nd == nil {
+		return &node[T]{edit: edit, children: make([]*node[T], width)}
+	}
+	if edit != nil && nd.edit == edit {
+		return nd
+	}
+	c := &node[T]{edit: edit}
+	if nd.children != nil {
+		c.children = make([]*node[T], width)
+		copy(c.children, nd.children)
+	}
+	if nd.values != nil {
+		c.values = make([]T, width)
+		copy(c.values, nd.values)
+	}
+	return c
+}
+
+// newPath returns a chain of internal nodes from level down to the
+// leaf nd.
+func newPath[T any](edit *owner, level uint, nd *node[T]) *node[T] {
+	if level == 0 {
+		return nd
+	}
+	ret := editable[T](edit, nil)
+	ret.children[0] = newPath(edit, level-bits, nd)
+	return ret
+}
+
+// pushTail inserts the full tail leaf of a vector of length n into the
+// trie, returning the new root and shift.
+func pushTail[T any](edit *owner, root *node[T], shift uint, n int, leaf *node[T]) (*node[T], uint) {
+	if root != nil && (n>>bits) > (1<<shift) {
+		// The trie is full, so grow it by one level.
+		newRoot := editable[T](edit, nil)
+		newRoot.children[0] = root
+		newRoot.children[1] = newPath(edit, shift, leaf)
+		return newRoot, shift + bits
+	}
+	return doPushTail(edit, root, shift, n, leaf), shift
+}
+
+func doPushTail[T any](edit *owner, parent *node[T], level uint, n int, leaf *node[T]) *node[T] {
+	sub := ((n - 1) >> level) & mask
+	ret := editable(edit, parent)
+	switch {
+	case level == bits:
+		ret.children[sub] = leaf
+	case ret.children[sub] != nil:
+		ret.children[sub] = doPushTail(edit, ret.children[sub], level-bits, n, leaf)
+	default:
+		ret.children[sub] = newPath(edit, level-bits, leaf)
+	}
+	return ret
+}
+
+// assoc sets the ith element in the trie rooted at nd, returning the
+// new root.
+func assoc[T any](edit *owner, nd *node[T], level uint, i int, value T) *node[T] {
+	ret := editable(edit, nd)
+	if level == 0 {
+		ret.values[i&mask] = value
+		return ret
+	}
+	sub := (i >> level) & mask
+	ret.children[sub] = assoc(edit, nd.children[sub], level-bits, i, value)
+	return ret
+}
+
+// popTail removes the last leaf from the trie of a vector of length n,
+// returning the new root and shift.
+func popTail[T any](edit *owner, root *node[T], shift uint, n int) (*node[T], uint) {
+	newRoot := doPopTail(edit, root, shift, n)
+	if shift > bits && newRoot.children[1] == nil {
+		return newRoot.children[0], shift - bits
+	}
+	return newRoot, shift
+}
+
+func doPopTail[T any](edit *owner, nd *node[T], level uint, n int) *node[T] {
+	sub := ((n - 2) >> level) & mask
+	if level > bits {
+		child := doPopTail(edit, nd.children[sub], level-bits, n)
+		if child == nil && sub == 0 {
+			return nil
+		}
+		ret := editable(edit, nd)
+		ret.children[sub] = child
+		return ret
+	}
+	if sub == 0 {
+		return nil
+	}
+	ret := editable(edit, nd)
+	ret.children[sub] = nil
+	return ret
+}
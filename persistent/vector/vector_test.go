@@ -0,0 +1,207 @@
+package vector_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/linhns/gocontainers/persistent/vector"
+	seqvector "github.com/linhns/gocontainers/vector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorBasics(t *testing.T) {
+	t.Parallel()
+
+	var v vector.Vector[int]
+	assert.True(t, v.Empty())
+	assert.Equal(t, 0, v.Len())
+	assert.Same(t, &v, v.Pop())
+
+	_, ok := v.Front()
+	assert.False(t, ok)
+	_, ok = v.Back()
+	assert.False(t, ok)
+
+	v1 := v.Append(1)
+	v2 := v1.Append(2)
+	v3 := v2.Set(0, 10)
+	assert.Equal(t, 1, v1.Len())
+	assert.Equal(t, []int{1}, slices.Collect(v1.Values()))
+	assert.Equal(t, []int{1, 2}, slices.Collect(v2.Values()))
+	assert.Equal(t, []int{10, 2}, slices.Collect(v3.Values()))
+
+	val, ok := v3.Front()
+	assert.True(t, ok)
+	assert.Equal(t, 10, val)
+	val, ok = v3.Back()
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+
+	_, ok = v3.Get(2)
+	assert.False(t, ok)
+	_, ok = v3.Get(-1)
+	assert.False(t, ok)
+
+	assert.Equal(t, []int{10}, slices.Collect(v3.Pop().Values()))
+	assert.Equal(t, []int{10, 2}, slices.Collect(v3.Values()))
+
+	assert.PanicsWithValue(t, "vector.Set: index out of range", func() {
+		v3.Set(2, 0)
+	})
+}
+
+func TestVectorPersistence(t *testing.T) {
+	t.Parallel()
+
+	// Keep every version and check that none of them changes as the
+	// vector grows through several levels of the trie and shrinks back.
+	const n = 40000
+	versions := []*vector.Vector[int]{vector.New[int]()}
+	for i := range n {
+		versions = append(versions, versions[i].Append(i))
+	}
+	for i := n; i > 0; i-- {
+		versions = append(versions, versions[len(versions)-1].Pop())
+	}
+	for i, v := range versions {
+		size := min(i, 2*n-i)
+		assert.Equal(t, size, v.Len())
+		if size > 0 {
+			val, _ := v.Back()
+			assert.Equal(t, size-1, val)
+		}
+	}
+	for i := 997; i <= n; i += 997 {
+		assert.Equal(t, seqRange(i), slices.Collect(versions[i].Values()))
+	}
+
+	v := versions[n]
+	w := v.Set(0, -1).Set(n/2, -1).Set(n-1, -1)
+	for _, i := range []int{0, n / 2, n - 1} {
+		val, _ := v.Get(i)
+		assert.Equal(t, i, val)
+		val, _ = w.Get(i)
+		assert.Equal(t, -1, val)
+	}
+}
+
+func TestVectorModel(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(1, 2))
+	v := vector.New[int]()
+	var model []int
+	for range 20000 {
+		switch op := r.IntN(10); {
+		case op < 6:
+			x := r.Int()
+			v = v.Append(x)
+			model = append(model, x)
+		case op < 8:
+			if len(model) > 0 {
+				i, x := r.IntN(len(model)), r.Int()
+				v = v.Set(i, x)
+				model[i] = x
+			}
+		default:
+			v = v.Pop()
+			if len(model) > 0 {
+				model = model[:len(model)-1]
+			}
+		}
+		assert.Equal(t, len(model), v.Len())
+	}
+	assert.Equal(t, model, slices.Collect(v.Values()))
+	for i, x := range v.All() {
+		assert.Equal(t, model[i], x)
+	}
+}
+
+func TestTransient(t *testing.T) {
+	t.Parallel()
+
+	base := vector.Of(seqRange(100)...)
+	tr := base.Transient()
+	for i := 100; i < 5000; i++ {
+		tr.Append(i)
+	}
+	for i := 0; i < 5000; i += 2 {
+		tr.Set(i, -i)
+	}
+	for range 1000 {
+		tr.Pop()
+	}
+	assert.Equal(t, 4000, tr.Len())
+	val, ok := tr.Get(10)
+	assert.True(t, ok)
+	assert.Equal(t, -10, val)
+
+	v := tr.Persistent()
+	assert.Equal(t, seqRange(100), slices.Collect(base.Values()))
+	assert.Equal(t, 4000, v.Len())
+	for i, x := range v.All() {
+		if i%2 == 0 {
+			assert.Equal(t, -i, x)
+		} else {
+			assert.Equal(t, i, x)
+		}
+	}
+
+	assert.PanicsWithValue(t, "vector: transient used after Persistent", func() {
+		tr.Append(0)
+	})
+
+	// A second transient must not modify nodes shared with v.
+	tr = v.Transient()
+	tr.Set(0, 1)
+	tr.Set(3000, 1)
+	w := tr.Persistent()
+	val, _ = v.Get(3000)
+	assert.Equal(t, -3000, val)
+	val, _ = w.Get(3000)
+	assert.Equal(t, 1, val)
+}
+
+func TestConversion(t *testing.T) {
+	t.Parallel()
+
+	s := seqvector.Of(seqRange(1000)...)
+	v := vector.FromVector(s)
+	s.Set(0, -1)
+	val, _ := v.Get(0)
+	assert.Equal(t, 0, val)
+
+	back := v.ToVector()
+	assert.Equal(t, seqRange(1000), slices.Collect(back.Values()))
+
+	assert.Equal(t, seqRange(50), slices.Collect(vector.Collect(slices.Values(seqRange(50))).Values()))
+}
+
+func seqRange(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+func BenchmarkAppend(b *testing.B) {
+	b.Run("persistent", func(b *testing.B) {
+		for range b.N {
+			v := vector.New[int]()
+			for i := range 1000 {
+				v = v.Append(i)
+			}
+		}
+	})
+	b.Run("transient", func(b *testing.B) {
+		for range b.N {
+			t := vector.New[int]().Transient()
+			for i := range 1000 {
+				t.Append(i)
+			}
+			t.Persistent()
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// isHeap reports whether pq.data satisfies the heap invariant: no element
+// has a higher priority than its parent.
+func (pq *PriorityQueue[T]) isHeap() bool {
+	for i := 1; i < len(pq.data); i++ {
+		if pq.comparator(pq.data[i], pq.data[(i-1)/2]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestHeapInvariant(t *testing.T) {
+	prop := func(ops []int8) bool {
+		pq := New(cmp.Compare[int8])
+		for _, op := range ops {
+			if op < 0 && op%3 == 0 {
+				pq.Pop()
+			} else {
+				pq.Push(op)
+			}
+			if !pq.isHeap() {
+				return false
+			}
+		}
+		for !pq.Empty() {
+			pq.Pop()
+			if !pq.isHeap() {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(prop, &quick.Config{MaxCount: 500}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHeapifyInvariant(t *testing.T) {
+	prop := func(vals []int8, batches [][]int8) bool {
+		pq := FromSlice(vals, cmp.Compare[int8])
+		if !pq.isHeap() {
+			return false
+		}
+		for _, b := range batches {
+			pq.PushAll(slices.Values(b))
+			if !pq.isHeap() {
+				return false
+			}
+		}
+		pq.Merge(pq.Clone())
+		return pq.isHeap()
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
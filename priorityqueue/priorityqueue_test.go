@@ -2,10 +2,17 @@ package priorityqueue_test
 
 import (
 	"cmp"
+	"fmt"
 	"math/rand/v2"
 	"slices"
 	"testing"
 
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/daryheap"
+	"github.com/linhns/gocontainers/fibonacciheap"
+	"github.com/linhns/gocontainers/pairingheap"
 	"github.com/linhns/gocontainers/priorityqueue"
 	"github.com/stretchr/testify/assert"
 )
@@ -89,3 +96,214 @@ func TestPriorityQueueCustomType(t *testing.T) {
 	_, ok = pq.Top()
 	assert.False(t, ok)
 }
+
+func TestPriorityQueueCollect(t *testing.T) {
+	nums := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	pq := priorityqueue.Collect(slices.Values(nums), cmp.Compare[int])
+	assert.Equal(t, len(nums), pq.Len())
+
+	slices.Sort(nums)
+	slices.Reverse(nums)
+	for _, want := range nums {
+		val, ok := pq.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, want, val)
+	}
+}
+
+func TestPriorityQueueClear(t *testing.T) {
+	pq := priorityqueue.Collect(slices.Values([]int{3, 1, 2}), cmp.Compare[int])
+	pq.Clear()
+	assert.True(t, pq.Empty())
+
+	pq.Push(1)
+	val, ok := pq.Top()
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+}
+
+func TestPriorityQueueFromSlice(t *testing.T) {
+	nums := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	pq := priorityqueue.FromSlice(nums, cmp.Compare[int])
+	assert.Equal(t, []int{3, 1, 4, 1, 5, 9, 2, 6}, nums)
+	assert.Equal(t, []int{9, 6, 5, 4, 3, 2, 1, 1}, slices.Collect(pq.Drain()))
+	assert.True(t, pq.Empty())
+
+	pq = priorityqueue.FromSlice([]int(nil), cmp.Compare[int])
+	assert.True(t, pq.Empty())
+}
+
+func TestPriorityQueuePushAllMerge(t *testing.T) {
+	pq := priorityqueue.FromSlice([]int{5, 1}, cmp.Compare[int])
+	pq.PushAll(slices.Values([]int{3}))
+	pq.PushAll(slices.Values([]int{8, 2, 7, 0}))
+
+	other := priorityqueue.FromSlice([]int{4, 6}, cmp.Compare[int])
+	pq.Merge(other)
+	assert.Equal(t, 2, other.Len())
+
+	assert.Equal(t, []int{8, 7, 6, 5, 4, 3, 2, 1, 0}, slices.Collect(pq.Drain()))
+
+	other.Merge(other)
+	other.PushAll(other.All())
+	assert.Equal(t, []int{6, 6, 6, 6, 4, 4, 4, 4}, slices.Collect(other.Drain()))
+}
+
+func TestPriorityQueueCloneAllDrain(t *testing.T) {
+	pq := priorityqueue.FromSlice([]int{3, 1, 2}, cmp.Compare[int])
+	c := pq.Clone()
+	c.Push(10)
+	assert.Equal(t, 3, pq.Len())
+	assert.ElementsMatch(t, []int{3, 1, 2}, slices.Collect(pq.All()))
+	assert.ElementsMatch(t, []int{10, 3, 1, 2}, slices.Collect(c.All()))
+
+	for v := range c.Drain() {
+		assert.Equal(t, 10, v)
+		break
+	}
+	assert.Equal(t, 3, c.Len())
+	for range c.All() {
+		break
+	}
+}
+
+func BenchmarkBuild(b *testing.B) {
+	nums := rand.Perm(100000)
+	b.Run("push", func(b *testing.B) {
+		for range b.N {
+			pq := priorityqueue.New(cmp.Compare[int])
+			for _, v := range nums {
+				pq.Push(v)
+			}
+		}
+	})
+	b.Run("heapify", func(b *testing.B) {
+		for range b.N {
+			priorityqueue.FromSlice(nums, cmp.Compare[int])
+		}
+	})
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestPriorityQueue(t, priorityqueue.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestPriorityQueueModel(t, priorityqueue.New[int])
+}
+
+func FuzzPriorityQueue(f *testing.F) {
+	containertest.FuzzPriorityQueue(f, priorityqueue.New[int])
+}
+
+// benchmarkPushPop measures pushing 10000 values into a priority queue
+// created by newPQ and popping them all.
+func benchmarkPushPop(b *testing.B, newPQ func() container.PriorityQueue[int]) {
+	nums := rand.Perm(10000)
+	pq := newPQ()
+	b.ResetTimer()
+	for range b.N {
+		for _, v := range nums {
+			pq.Push(v)
+		}
+		for !pq.Empty() {
+			pq.Pop()
+		}
+	}
+}
+
+func BenchmarkHeapPushPop(b *testing.B) {
+	b.Run("binary", func(b *testing.B) {
+		benchmarkPushPop(b, func() container.PriorityQueue[int] { return priorityqueue.New(cmp.Compare[int]) })
+	})
+	for _, arity := range []int{2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("dary/arity=%d", arity), func(b *testing.B) {
+			benchmarkPushPop(b, func() container.PriorityQueue[int] { return daryheap.NewWithArity(cmp.Compare[int], arity) })
+		})
+	}
+	b.Run("pairing", func(b *testing.B) {
+		benchmarkPushPop(b, func() container.PriorityQueue[int] { return pairingheap.New(cmp.Compare[int]) })
+	})
+	b.Run("fibonacci", func(b *testing.B) {
+		benchmarkPushPop(b, func() container.PriorityQueue[int] { return fibonacciheap.New(cmp.Compare[int]) })
+	})
+}
+
+// benchmarkMeld measures melding 10 heaps of 1000 values each, created by
+// newPQ, into an empty one.
+func benchmarkMeld[P interface {
+	container.PriorityQueue[int]
+	Meld(P)
+}](b *testing.B, newPQ func(comparator.Comparator[int]) P) {
+	nums := rand.Perm(1000)
+	for range b.N {
+		pq := newPQ(cmp.Compare[int])
+		for range 10 {
+			other := newPQ(cmp.Compare[int])
+			for _, v := range nums {
+				other.Push(v)
+			}
+			pq.Meld(other)
+		}
+	}
+}
+
+func BenchmarkHeapMeld(b *testing.B) {
+	b.Run("binary", func(b *testing.B) {
+		benchmarkMeld(b, func(cmp comparator.Comparator[int]) *mergeable { return &mergeable{priorityqueue.New(cmp)} })
+	})
+	for _, arity := range []int{2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("dary/arity=%d", arity), func(b *testing.B) {
+			benchmarkMeld(b, func(cmp comparator.Comparator[int]) *daryheap.DaryHeap[int] {
+				return daryheap.NewWithArity(cmp, arity)
+			})
+		})
+	}
+	b.Run("pairing", func(b *testing.B) {
+		benchmarkMeld(b, pairingheap.New[int])
+	})
+	b.Run("fibonacci", func(b *testing.B) {
+		benchmarkMeld(b, fibonacciheap.New[int])
+	})
+}
+
+// mergeable adapts [priorityqueue.PriorityQueue.Merge] to the Meld method
+// of the other heaps.
+type mergeable struct {
+	*priorityqueue.PriorityQueue[int]
+}
+
+func (m *mergeable) Meld(other *mergeable) {
+	m.Merge(other.PriorityQueue)
+}
+
+// benchmarkUpdate measures raising the priority of every element of a heap
+// of 10000 once, as Dijkstra's algorithm does when it finds shorter paths.
+func benchmarkUpdate[H containertest.Handle[int], P containertest.AddressablePriorityQueue[H, P]](b *testing.B, newPQ func(comparator.Comparator[int]) P) {
+	nums := rand.Perm(10000)
+	elems := make([]H, len(nums))
+	for range b.N {
+		pq := newPQ(cmp.Compare[int])
+		for i, v := range nums {
+			elems[i] = pq.Insert(v)
+		}
+		pq.Pop()
+		for _, e := range elems {
+			if e.Value() < len(nums)-1 {
+				pq.Update(e, e.Value()+len(nums))
+			}
+		}
+		for !pq.Empty() {
+			pq.Pop()
+		}
+	}
+}
+
+func BenchmarkHeapUpdate(b *testing.B) {
+	b.Run("pairing", func(b *testing.B) {
+		benchmarkUpdate[*pairingheap.Element[int]](b, pairingheap.New[int])
+	})
+	b.Run("fibonacci", func(b *testing.B) {
+		benchmarkUpdate[*fibonacciheap.Element[int]](b, fibonacciheap.New[int])
+	})
+}
@@ -0,0 +1,93 @@
+package priorityqueue
+
+import (
+	"iter"
+
+	"github.com/linhns/gocontainers/comparator"
+	"github.com/linhns/gocontainers/container"
+)
+
+// StablePriorityQueue is a priority queue in which elements with equal
+// priorities are popped in the order they were pushed.
+type StablePriorityQueue[T any] struct {
+	pq  *PriorityQueue[stableEntry[T]]
+	seq uint64
+}
+
+var _ container.PriorityQueue[int] = (*StablePriorityQueue[int])(nil)
+
+// stableEntry is an element with its insertion sequence number, which
+// breaks ties between equal priorities.
+type stableEntry[T any] struct {
+	value T
+	seq   uint64
+}
+
+// NewStable creates a new [StablePriorityQueue] with the specified
+// comparator.
+func NewStable[T any](comparator comparator.Comparator[T]) *StablePriorityQueue[T] {
+	return &StablePriorityQueue[T]{
+		pq: New(func(a, b stableEntry[T]) int {
+			if c := comparator(a.value, b.value); c != 0 {
+				return c
+			}
+			// The earlier entry has the higher priority.
+			switch {
+			case a.seq < b.seq:
+				return 1
+			case a.seq > b.seq:
+				return -1
+			}
+			return 0
+		}),
+	}
+}
+
+// Len returns the number of elements in the priority queue.
+func (pq *StablePriorityQueue[T]) Len() int {
+	return pq.pq.Len()
+}
+
+// Empty reports whether the priority queue is empty.
+func (pq *StablePriorityQueue[T]) Empty() bool {
+	return pq.pq.Empty()
+}
+
+// Clear removes all elements from the priority queue.
+func (pq *StablePriorityQueue[T]) Clear() {
+	pq.pq.Clear()
+}
+
+// Push adds an element to the priority queue.
+func (pq *StablePriorityQueue[T]) Push(v T) {
+	pq.pq.Push(stableEntry[T]{v, pq.seq})
+	pq.seq++
+}
+
+// Top returns the element with the maximal priority in the queue that
+// was pushed first. If the queue is empty, it returns the zero value of
+// the element type and false.
+func (pq *StablePriorityQueue[T]) Top() (T, bool) {
+	e, ok := pq.pq.Top()
+	return e.value, ok
+}
+
+// Pop returns the element with the maximal priority in the queue that
+// was pushed first, and removes it from the queue. If the queue is empty,
+// it returns the zero value of the element type and false.
+func (pq *StablePriorityQueue[T]) Pop() (T, bool) {
+	e, ok := pq.pq.Pop()
+	return e.value, ok
+}
+
+// All returns an iterator over the elements in the priority queue in
+// unspecified order.
+func (pq *StablePriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range pq.pq.All() {
+			if !yield(e.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package priorityqueue_test
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/priorityqueue"
+	"github.com/stretchr/testify/assert"
+)
+
+type job struct {
+	priority int
+	id       int
+}
+
+func byPriority(a, b job) int {
+	return cmp.Compare(a.priority, b.priority)
+}
+
+func TestStablePriorityQueue(t *testing.T) {
+	pq := priorityqueue.NewStable(byPriority)
+	_, ok := pq.Top()
+	assert.False(t, ok)
+
+	// Pushing many equal priorities makes an unstable heap reorder them.
+	for id := range 1000 {
+		pq.Push(job{rand.IntN(3), id})
+	}
+	assert.Equal(t, 1000, pq.Len())
+
+	last := job{3, -1}
+	for !pq.Empty() {
+		top, _ := pq.Top()
+		j, _ := pq.Pop()
+		assert.Equal(t, top, j)
+		assert.LessOrEqual(t, j.priority, last.priority)
+		if j.priority == last.priority {
+			assert.Greater(t, j.id, last.id)
+		}
+		last = j
+	}
+
+	pq.Push(job{1, 0})
+	pq.Push(job{1, 1})
+	assert.ElementsMatch(t, []job{{1, 0}, {1, 1}}, slices.Collect(pq.All()))
+	pq.Clear()
+	assert.True(t, pq.Empty())
+}
+
+func TestStableConformance(t *testing.T) {
+	containertest.TestPriorityQueue(t, priorityqueue.NewStable[int])
+}
+
+func TestStableModel(t *testing.T) {
+	containertest.TestPriorityQueueModel(t, priorityqueue.NewStable[int])
+}
@@ -0,0 +1,138 @@
+package priorityqueue
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/linhns/gocontainers/comparator"
+)
+
+// TopKCollector keeps the k elements with the highest priority among the
+// elements added to it, using O(k) memory.
+type TopKCollector[T any] struct {
+	k          int
+	comparator comparator.Comparator[T]
+	// heap holds the elements kept so far with the lowest priority on top.
+	heap *PriorityQueue[T]
+}
+
+// NewTopKCollector creates a new [TopKCollector] that keeps the k
+// elements with the highest priority according to comparator.
+//
+// If k is negative, NewTopKCollector panics.
+func NewTopKCollector[T any](k int, comparator comparator.Comparator[T]) *TopKCollector[T] {
+	if k < 0 {
+		panic("priorityqueue.NewTopKCollector: negative k")
+	}
+	return &TopKCollector[T]{
+		k:          k,
+		comparator: comparator,
+		heap:       New(reverse(comparator)),
+	}
+}
+
+// Add offers v to the collector in O(log k) time. v is kept if fewer than
+// k elements have been kept so far or if it has a higher priority than
+// the lowest kept element, which is then discarded.
+func (c *TopKCollector[T]) Add(v T) {
+	h := c.heap
+	switch {
+	case len(h.data) < c.k:
+		h.Push(v)
+	case c.k > 0 && c.comparator(v, h.data[0]) > 0:
+		h.data[0] = v
+		h.siftDown(0)
+	}
+}
+
+// AddAll offers all elements from an iterator to the collector.
+func (c *TopKCollector[T]) AddAll(seq iter.Seq[T]) {
+	for v := range seq {
+		c.Add(v)
+	}
+}
+
+// Len returns the number of elements kept, which is at most k.
+func (c *TopKCollector[T]) Len() int {
+	return len(c.heap.data)
+}
+
+// Result returns the elements kept, from the highest priority to the
+// lowest. The collector is not modified.
+func (c *TopKCollector[T]) Result() []T {
+	result := slices.Clone(c.heap.data)
+	slices.SortFunc(result, reverse(c.comparator))
+	return result
+}
+
+// TopK returns the k elements of seq with the highest priority according
+// to comparator, from the highest to the lowest. It runs in O(n log k)
+// time and O(k) memory.
+//
+// If k is negative, TopK panics.
+func TopK[T any](seq iter.Seq[T], k int, comparator comparator.Comparator[T]) []T {
+	c := NewTopKCollector(k, comparator)
+	c.AddAll(seq)
+	return c.Result()
+}
+
+// NLargest returns the n largest elements of seq according to comparator,
+// in descending order. It is the same as [TopK].
+func NLargest[T any](seq iter.Seq[T], n int, comparator comparator.Comparator[T]) []T {
+	return TopK(seq, n, comparator)
+}
+
+// NSmallest returns the n smallest elements of seq according to
+// comparator, in ascending order.
+func NSmallest[T any](seq iter.Seq[T], n int, comparator comparator.Comparator[T]) []T {
+	return TopK(seq, n, reverse(comparator))
+}
+
+// MergeSorted returns an iterator that merges seqs, each sorted in
+// ascending order according to comparator, into a single sorted sequence.
+// Equal elements are yielded in the order of the sequences they come
+// from.
+//
+// Merging k sequences takes O(log k) time per element.
+func MergeSorted[T any](comparator comparator.Comparator[T], seqs ...iter.Seq[T]) iter.Seq[T] {
+	type head struct {
+		value T
+		index int
+	}
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), len(seqs))
+		heads := New(func(a, b head) int {
+			if c := comparator(b.value, a.value); c != 0 {
+				return c
+			}
+			return b.index - a.index
+		})
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[i] = next
+			if v, ok := next(); ok {
+				heads.Push(head{v, i})
+			}
+		}
+
+		for {
+			h, ok := heads.Top()
+			if !ok || !yield(h.value) {
+				return
+			}
+			if v, ok := nexts[h.index](); ok {
+				heads.data[0] = head{v, h.index}
+				heads.siftDown(0)
+			} else {
+				heads.Pop()
+			}
+		}
+	}
+}
+
+// reverse is [comparator.Reverse], for use where a parameter shadows the
+// package name.
+func reverse[T any](cmp comparator.Comparator[T]) comparator.Comparator[T] {
+	return comparator.Reverse(cmp)
+}
@@ -0,0 +1,87 @@
+package priorityqueue_test
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/linhns/gocontainers/priorityqueue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopK(t *testing.T) {
+	nums := rand.Perm(1000)
+	assert.Equal(t, []int{999, 998, 997}, priorityqueue.TopK(slices.Values(nums), 3, cmp.Compare[int]))
+	assert.Equal(t, []int{999, 998, 997}, priorityqueue.NLargest(slices.Values(nums), 3, cmp.Compare[int]))
+	assert.Equal(t, []int{0, 1, 2, 3}, priorityqueue.NSmallest(slices.Values(nums), 4, cmp.Compare[int]))
+
+	assert.Empty(t, priorityqueue.TopK(slices.Values(nums), 0, cmp.Compare[int]))
+	assert.Equal(t, []int{3, 2, 1}, priorityqueue.TopK(slices.Values([]int{2, 3, 1}), 10, cmp.Compare[int]))
+
+	assert.PanicsWithValue(t, "priorityqueue.NewTopKCollector: negative k", func() {
+		priorityqueue.TopK(slices.Values(nums), -1, cmp.Compare[int])
+	})
+}
+
+func TestTopKCollector(t *testing.T) {
+	c := priorityqueue.NewTopKCollector(3, func(a, b string) int {
+		return cmp.Compare(len(a), len(b))
+	})
+	c.AddAll(slices.Values([]string{"a", "bbbb", "cc"}))
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, []string{"bbbb", "cc", "a"}, c.Result())
+
+	c.Add("ddd")
+	c.Add("e")
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, []string{"bbbb", "ddd", "cc"}, c.Result())
+	assert.Equal(t, []string{"bbbb", "ddd", "cc"}, c.Result())
+}
+
+func TestMergeSorted(t *testing.T) {
+	seqs := []iter.Seq[int]{
+		slices.Values([]int{1, 4, 7, 10}),
+		slices.Values([]int{}),
+		slices.Values([]int{2, 5, 8}),
+		slices.Values([]int{0, 3, 6, 9, 11, 12}),
+	}
+	want := make([]int, 13)
+	for i := range want {
+		want[i] = i
+	}
+	assert.Equal(t, want, slices.Collect(priorityqueue.MergeSorted(cmp.Compare[int], seqs...)))
+	assert.Empty(t, slices.Collect(priorityqueue.MergeSorted(cmp.Compare[int])))
+
+	// Equal elements keep the order of their sequences.
+	byFirst := func(a, b string) int { return cmp.Compare(a[0], b[0]) }
+	merged := priorityqueue.MergeSorted(byFirst,
+		slices.Values([]string{"a1", "b1"}),
+		slices.Values([]string{"a2", "b2"}),
+		slices.Values([]string{"a3"}),
+	)
+	assert.Equal(t, "a1 a2 a3 b1 b2", strings.Join(slices.Collect(merged), " "))
+
+	for v := range priorityqueue.MergeSorted(cmp.Compare[int], seqs...) {
+		assert.Equal(t, 0, v)
+		break
+	}
+}
+
+func TestMergeSortedRandom(t *testing.T) {
+	var seqs []iter.Seq[int]
+	var all []int
+	for range 20 {
+		s := make([]int, 1+rand.IntN(50))
+		for i := range s {
+			s[i] = rand.IntN(100)
+		}
+		slices.Sort(s)
+		seqs = append(seqs, slices.Values(s))
+		all = append(all, s...)
+	}
+	slices.Sort(all)
+	assert.Equal(t, all, slices.Collect(priorityqueue.MergeSorted(cmp.Compare[int], seqs...)))
+}
@@ -1,11 +1,19 @@
 // Package queue implements a simple queue data structure.
 package queue
 
+import (
+	"iter"
+
+	"github.com/linhns/gocontainers/container"
+)
+
 // A Queue is a FIFO data structure.
 type Queue[T any] struct {
 	data []T
 }
 
+var _ container.Queue[int] = (*Queue[int])(nil)
+
 // New creates and initializes a new [Queue].
 func New[T any]() *Queue[T] {
 	return &Queue[T]{}
@@ -21,6 +29,12 @@ func (q *Queue[T]) Len() int {
 	return len(q.data)
 }
 
+// Clear removes all elements from the queue.
+func (q *Queue[T]) Clear() {
+	clear(q.data)
+	q.data = q.data[:0]
+}
+
 // Push adds an element to the back of the queue.
 func (q *Queue[T]) Push(val T) {
 	q.data = append(q.data, val)
@@ -52,3 +66,13 @@ func (q *Queue[T]) Pop() (T, bool) {
 	q.data = q.data[1:]
 	return val, true
 }
+
+// Collect collects values from an iterator into a new queue.
+// The first value yielded is at the front of the queue.
+func Collect[T any](seq iter.Seq[T]) *Queue[T] {
+	q := New[T]()
+	for v := range seq {
+		q.data = append(q.data, v)
+	}
+	return q
+}
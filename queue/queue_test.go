@@ -1,8 +1,10 @@
 package queue_test
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/linhns/gocontainers/containertest"
 	"github.com/linhns/gocontainers/queue"
 	"github.com/stretchr/testify/assert"
 )
@@ -34,3 +36,38 @@ func TestQueue(t *testing.T) {
 	_, ok = q.Front()
 	assert.False(t, ok)
 }
+
+func TestQueueCollect(t *testing.T) {
+	q := queue.Collect(slices.Values([]int{1, 2, 3}))
+	assert.Equal(t, 3, q.Len())
+
+	for want := 1; want <= 3; want++ {
+		val, ok := q.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, want, val)
+	}
+}
+
+func TestQueueClear(t *testing.T) {
+	q := queue.Collect(slices.Values([]int{1, 2, 3}))
+	q.Pop()
+	q.Clear()
+	assert.True(t, q.Empty())
+
+	q.Push(4)
+	val, ok := q.Front()
+	assert.True(t, ok)
+	assert.Equal(t, 4, val)
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestQueue(t, queue.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestQueueModel(t, queue.New[int])
+}
+
+func FuzzQueue(f *testing.F) {
+	containertest.FuzzQueue(f, queue.New[int])
+}
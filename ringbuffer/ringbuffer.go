@@ -0,0 +1,204 @@
+// Package ringbuffer implements a circular buffer with a fixed capacity.
+package ringbuffer
+
+import (
+	"iter"
+
+	"github.com/linhns/gocontainers/container"
+)
+
+// Mode determines what happens when an element is pushed into a full
+// [RingBuffer].
+type Mode int
+
+const (
+	// Reject discards the pushed element, keeping the buffer unchanged.
+	Reject Mode = iota
+	// Overwrite discards the oldest element to make room for the pushed
+	// element.
+	Overwrite
+)
+
+// String returns the name of the mode.
+func (m Mode) String() string {
+	switch m {
+	case Reject:
+		return "Reject"
+	case Overwrite:
+		return "Overwrite"
+	}
+	return "Mode(?)"
+}
+
+// A RingBuffer is a FIFO data structure with a fixed capacity, useful for
+// keeping the most recent elements of a stream.
+//
+// Elements are indexed from the oldest, at index 0, to the newest.
+type RingBuffer[T any] struct {
+	data []T
+	// head is the index in data of the oldest element.
+	head int
+	len  int
+	mode Mode
+}
+
+var _ container.Queue[int] = (*RingBuffer[int])(nil)
+
+// New creates a new [RingBuffer] that holds at most capacity elements and
+// handles pushes into a full buffer according to mode.
+//
+// If capacity is not positive, New panics.
+func New[T any](capacity int, mode Mode) *RingBuffer[T] {
+	if capacity <= 0 {
+		panic("ringbuffer.New: non-positive capacity")
+	}
+	return &RingBuffer[T]{
+		data: make([]T, capacity),
+		mode: mode,
+	}
+}
+
+// Collect collects values from an iterator into a new [RingBuffer] with
+// the specified capacity and mode. The first value yielded is the oldest.
+//
+// If capacity is not positive, Collect panics.
+func Collect[T any](seq iter.Seq[T], capacity int, mode Mode) *RingBuffer[T] {
+	b := New[T](capacity, mode)
+	for v := range seq {
+		b.Push(v)
+	}
+	return b
+}
+
+// Mode returns the mode of the buffer.
+func (b *RingBuffer[T]) Mode() Mode {
+	return b.mode
+}
+
+// Len returns the number of elements in the buffer.
+func (b *RingBuffer[T]) Len() int {
+	return b.len
+}
+
+// Cap returns the maximum number of elements in the buffer.
+func (b *RingBuffer[T]) Cap() int {
+	return len(b.data)
+}
+
+// Empty reports whether the buffer is empty.
+func (b *RingBuffer[T]) Empty() bool {
+	return b.len == 0
+}
+
+// Full reports whether the buffer holds Cap elements.
+func (b *RingBuffer[T]) Full() bool {
+	return b.len == len(b.data)
+}
+
+// Clear removes all elements from the buffer.
+func (b *RingBuffer[T]) Clear() {
+	clear(b.data)
+	b.head = 0
+	b.len = 0
+}
+
+// Push adds an element to the back of the buffer. If the buffer is full,
+// either v or the oldest element is discarded, depending on the mode.
+func (b *RingBuffer[T]) Push(v T) {
+	b.Offer(v)
+}
+
+// Offer adds an element to the back of the buffer, like Push. If the
+// buffer was full, it returns the discarded element, which is v itself in
+// [Reject] mode and the oldest element in [Overwrite] mode, and true.
+func (b *RingBuffer[T]) Offer(v T) (discarded T, ok bool) {
+	if b.len < len(b.data) {
+		b.data[b.index(b.len)] = v
+		b.len++
+		return discarded, false
+	}
+	if b.mode == Reject {
+		return v, true
+	}
+	discarded = b.data[b.head]
+	b.data[b.head] = v
+	b.head = b.index(1)
+	return discarded, true
+}
+
+// Front returns the oldest element in the buffer.
+//
+// It returns the zero value of T and false if the buffer is empty.
+// Otherwise, it returns the element and true.
+func (b *RingBuffer[T]) Front() (T, bool) {
+	return b.Get(0)
+}
+
+// Back returns the newest element in the buffer.
+//
+// It returns the zero value of T and false if the buffer is empty.
+// Otherwise, it returns the element and true.
+func (b *RingBuffer[T]) Back() (T, bool) {
+	return b.Get(b.len - 1)
+}
+
+// Get returns the element at index i, counting from the oldest element.
+//
+// It returns the zero value of T and false if i is out of range.
+// Otherwise, it returns the element and true.
+func (b *RingBuffer[T]) Get(i int) (T, bool) {
+	if i < 0 || i >= b.len {
+		var zero T
+		return zero, false
+	}
+	return b.data[b.index(i)], true
+}
+
+// Pop removes and returns the oldest element in the buffer.
+//
+// It returns the zero value of T and false if the buffer is empty.
+// Otherwise, it returns the element and true.
+func (b *RingBuffer[T]) Pop() (T, bool) {
+	var zero T
+	if b.len == 0 {
+		return zero, false
+	}
+	v := b.data[b.head]
+	b.data[b.head] = zero
+	b.head = b.index(1)
+	b.len--
+	return v, true
+}
+
+// Values returns an iterator over the elements in the buffer, from the
+// oldest to the newest.
+func (b *RingBuffer[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range b.len {
+			if !yield(b.data[b.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over index-value pairs in the buffer, from the
+// oldest to the newest.
+func (b *RingBuffer[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range b.len {
+			if !yield(i, b.data[b.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// index returns the position in data of the element at index i.
+func (b *RingBuffer[T]) index(i int) int {
+	i += b.head
+	if i >= len(b.data) {
+		i -= len(b.data)
+	}
+	return i
+}
@@ -0,0 +1,148 @@
+package ringbuffer_test
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/containertest"
+	"github.com/linhns/gocontainers/ringbuffer"
+)
+
+func TestRingBuffer(t *testing.T) {
+	b := ringbuffer.New[int](3, ringbuffer.Reject)
+	assert.True(t, b.Empty())
+	assert.Equal(t, 3, b.Cap())
+	assert.Equal(t, ringbuffer.Reject, b.Mode())
+
+	_, ok := b.Back()
+	assert.False(t, ok)
+
+	for i := 1; i <= 3; i++ {
+		_, discarded := b.Offer(i)
+		assert.False(t, discarded)
+	}
+	assert.True(t, b.Full())
+
+	v, discarded := b.Offer(4)
+	assert.True(t, discarded)
+	assert.Equal(t, 4, v)
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(b.Values()))
+
+	v, _ = b.Pop()
+	assert.Equal(t, 1, v)
+	b.Push(5)
+	assert.Equal(t, []int{2, 3, 5}, slices.Collect(b.Values()))
+
+	front, _ := b.Front()
+	back, _ := b.Back()
+	assert.Equal(t, 2, front)
+	assert.Equal(t, 5, back)
+
+	v, ok = b.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	_, ok = b.Get(3)
+	assert.False(t, ok)
+	_, ok = b.Get(-1)
+	assert.False(t, ok)
+}
+
+func TestRingBufferOverwrite(t *testing.T) {
+	b := ringbuffer.Collect(slices.Values([]int{1, 2, 3, 4, 5}), 3, ringbuffer.Overwrite)
+	assert.Equal(t, 3, b.Len())
+	assert.Equal(t, []int{3, 4, 5}, slices.Collect(b.Values()))
+
+	v, discarded := b.Offer(6)
+	assert.True(t, discarded)
+	assert.Equal(t, 3, v)
+
+	for i, v := range b.All() {
+		assert.Equal(t, i+4, v)
+	}
+
+	b.Clear()
+	assert.True(t, b.Empty())
+	b.Push(7)
+	assert.Equal(t, []int{7}, slices.Collect(b.Values()))
+}
+
+func TestRingBufferModel(t *testing.T) {
+	prop := func(ops []int8, capacity uint8, overwrite bool) bool {
+		mode := ringbuffer.Reject
+		if overwrite {
+			mode = ringbuffer.Overwrite
+		}
+		capacity = capacity%8 + 1
+		b := ringbuffer.New[int8](int(capacity), mode)
+		var model []int8
+		for _, op := range ops {
+			switch {
+			case op < -100:
+				b.Clear()
+				model = nil
+			case op < 0:
+				got, ok := b.Pop()
+				if ok != (len(model) > 0) || ok && got != model[0] {
+					return false
+				}
+				if ok {
+					model = model[1:]
+				}
+			default:
+				got, discarded := b.Offer(op)
+				full := len(model) == int(capacity)
+				want := op
+				switch {
+				case !full:
+					model = append(model, op)
+				case overwrite:
+					want = model[0]
+					model = append(model[1:], op)
+				}
+				if discarded != full || full && got != want {
+					return false
+				}
+			}
+			if !slices.Equal(slices.Collect(b.Values()), model) || b.Full() != (len(model) == int(capacity)) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(prop, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewPanics(t *testing.T) {
+	assert.PanicsWithValue(t, "ringbuffer.New: non-positive capacity", func() {
+		ringbuffer.New[int](0, ringbuffer.Overwrite)
+	})
+}
+
+func TestModeString(t *testing.T) {
+	assert.Equal(t, "Reject", ringbuffer.Reject.String())
+	assert.Equal(t, "Overwrite", ringbuffer.Overwrite.String())
+	assert.Equal(t, "Mode(?)", ringbuffer.Mode(-1).String())
+}
+
+// newUnbounded returns a buffer large enough to never fill up in the
+// conformance and model suites.
+func newUnbounded() *ringbuffer.RingBuffer[int] {
+	return ringbuffer.New[int](1<<16, ringbuffer.Reject)
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestQueue(t, newUnbounded)
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestQueueModel(t, newUnbounded)
+}
+
+func FuzzRingBuffer(f *testing.F) {
+	containertest.FuzzQueue(f, newUnbounded)
+}
@@ -0,0 +1,254 @@
+package segtree
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+
+	"github.com/linhns/gocontainers/vector"
+)
+
+// Action describes updates of type F applied to ranges of a
+// [LazySegTree] with elements of type T.
+//
+// Apply returns the result of applying f to v, the combination of n
+// consecutive elements. It must distribute over the combine function of
+// the tree:
+//
+//	Apply(f, Combine(a, b), n+m) == Combine(Apply(f, a, n), Apply(f, b, m))
+//
+// Compose returns the update that applies g and then f, and Identity is
+// the update that leaves every element unchanged.
+type Action[T, F any] struct {
+	Apply    func(f F, v T, n int) T
+	Compose  func(f, g F) F
+	Identity F
+}
+
+// LazySegTree is a segment tree over a fixed number of elements that also
+// supports applying an update to a range of elements in O(log n) time, by
+// deferring updates of whole subtrees until they are needed.
+type LazySegTree[T, F any] struct {
+	// tree holds the leaves in tree[size:size+n] and the combination of
+	// the children 2i and 2i+1 of an inner node i in tree[i]. size is a
+	// power of two so that every inner node covers a contiguous range.
+	tree []T
+	// lazy holds the update pending for the children of each inner node.
+	lazy   []F
+	n      int
+	size   int
+	log    int
+	monoid Monoid[T]
+	action Action[T, F]
+}
+
+// NewLazy creates a new [LazySegTree] with n elements, all set to the
+// identity of monoid.
+func NewLazy[T, F any](n int, monoid Monoid[T], action Action[T, F]) *LazySegTree[T, F] {
+	t := newLazy(n, monoid, action)
+	for i := range t.tree {
+		t.tree[i] = monoid.Identity
+	}
+	return t
+}
+
+// LazyFromVector creates a new [LazySegTree] with the elements of v in
+// O(n) time.
+func LazyFromVector[T, F any](v *vector.Vector[T], monoid Monoid[T], action Action[T, F]) *LazySegTree[T, F] {
+	return CollectLazy(v.Values(), monoid, action)
+}
+
+// CollectLazy collects values from an iterator into a new [LazySegTree]
+// in O(n) time.
+func CollectLazy[T, F any](seq iter.Seq[T], monoid Monoid[T], action Action[T, F]) *LazySegTree[T, F] {
+	leaves := slices.Collect(seq)
+	t := newLazy(len(leaves), monoid, action)
+	for i := range t.size {
+		t.tree[i] = monoid.Identity
+	}
+	copy(t.tree[t.size:], leaves)
+	for i := t.size + len(leaves); i < len(t.tree); i++ {
+		t.tree[i] = monoid.Identity
+	}
+	for i := t.size - 1; i > 0; i-- {
+		t.pull(i)
+	}
+	return t
+}
+
+func newLazy[T, F any](n int, monoid Monoid[T], action Action[T, F]) *LazySegTree[T, F] {
+	log := 0
+	if n > 1 {
+		log = bits.Len(uint(n - 1))
+	}
+	size := 1 << log
+	lazy := make([]F, size)
+	for i := range lazy {
+		lazy[i] = action.Identity
+	}
+	return &LazySegTree[T, F]{
+		tree:   make([]T, 2*size),
+		lazy:   lazy,
+		n:      n,
+		size:   size,
+		log:    log,
+		monoid: monoid,
+		action: action,
+	}
+}
+
+// Len returns the number of elements in the tree.
+func (t *LazySegTree[T, F]) Len() int {
+	return t.n
+}
+
+// Get returns the zero-indexed ith element of t, if any, in O(log n)
+// time.
+func (t *LazySegTree[T, F]) Get(i int) (T, bool) {
+	if i < 0 || i >= t.n {
+		var zero T
+		return zero, false
+	}
+	i += t.size
+	t.pushPath(i)
+	return t.tree[i], true
+}
+
+// Set sets the zero-indexed ith element of t to value in O(log n) time.
+//
+// Set panics if i is negative or greater than or equal to the length of t.
+func (t *LazySegTree[T, F]) Set(i int, value T) {
+	if i < 0 || i >= t.n {
+		panic("segtree.Set: index out of range")
+	}
+	i += t.size
+	t.pushPath(i)
+	t.tree[i] = value
+	for i > 1 {
+		i /= 2
+		t.pull(i)
+	}
+}
+
+// Query returns the combination of the elements with indices in [l, r)
+// in O(log n) time, or the identity if the range is empty.
+//
+// Query panics if l < 0, r > Len or l > r.
+func (t *LazySegTree[T, F]) Query(l, r int) T {
+	if l < 0 || r > t.n || l > r {
+		panic("segtree.Query: invalid range")
+	}
+	if l == r {
+		return t.monoid.Identity
+	}
+	l, r = l+t.size, r+t.size
+	t.pushBounds(l, r)
+
+	left, right := t.monoid.Identity, t.monoid.Identity
+	for ; l < r; l, r = l/2, r/2 {
+		if l%2 == 1 {
+			left = t.monoid.Combine(left, t.tree[l])
+			l++
+		}
+		if r%2 == 1 {
+			r--
+			right = t.monoid.Combine(t.tree[r], right)
+		}
+	}
+	return t.monoid.Combine(left, right)
+}
+
+// Apply applies the update f to the elements with indices in [l, r) in
+// O(log n) time.
+//
+// Apply panics if l < 0, r > Len or l > r.
+func (t *LazySegTree[T, F]) Apply(l, r int, f F) {
+	if l < 0 || r > t.n || l > r {
+		panic("segtree.Apply: invalid range")
+	}
+	if l == r {
+		return
+	}
+	l, r = l+t.size, r+t.size
+	t.pushBounds(l, r)
+
+	for l, r := l, r; l < r; l, r = l/2, r/2 {
+		if l%2 == 1 {
+			t.applyNode(l, f)
+			l++
+		}
+		if r%2 == 1 {
+			r--
+			t.applyNode(r, f)
+		}
+	}
+
+	// Recompute the ancestors of the boundary nodes, which cover the
+	// range only partially.
+	for i := 1; i <= t.log; i++ {
+		if (l>>i)<<i != l {
+			t.pull(l >> i)
+		}
+		if (r>>i)<<i != r {
+			t.pull((r - 1) >> i)
+		}
+	}
+}
+
+// Values returns an iterator over the elements of the tree. It applies
+// all pending updates first, in O(n) time.
+func (t *LazySegTree[T, F]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 1; i < t.size; i++ {
+			t.push(i)
+		}
+		for _, v := range t.tree[t.size : t.size+t.n] {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// pushPath pushes the pending updates of the ancestors of the leaf i down
+// to it, from the root.
+func (t *LazySegTree[T, F]) pushPath(i int) {
+	for j := t.log; j > 0; j-- {
+		t.push(i >> j)
+	}
+}
+
+// pushBounds pushes the pending updates of the ancestors of the
+// boundaries of the leaves [l, r) that cover the range only partially.
+func (t *LazySegTree[T, F]) pushBounds(l, r int) {
+	for i := t.log; i > 0; i-- {
+		if (l>>i)<<i != l {
+			t.push(l >> i)
+		}
+		if (r>>i)<<i != r {
+			t.push((r - 1) >> i)
+		}
+	}
+}
+
+// applyNode applies f to the node i and records it as pending for the
+// children of i.
+func (t *LazySegTree[T, F]) applyNode(i int, f F) {
+	t.tree[i] = t.action.Apply(f, t.tree[i], t.size>>(bits.Len(uint(i))-1))
+	if i < t.size {
+		t.lazy[i] = t.action.Compose(f, t.lazy[i])
+	}
+}
+
+// push applies the update pending for the children of the inner node i
+// to them.
+func (t *LazySegTree[T, F]) push(i int) {
+	t.applyNode(2*i, t.lazy[i])
+	t.applyNode(2*i+1, t.lazy[i])
+	t.lazy[i] = t.action.Identity
+}
+
+// pull recomputes the inner node i from its children.
+func (t *LazySegTree[T, F]) pull(i int) {
+	t.tree[i] = t.monoid.Combine(t.tree[2*i], t.tree[2*i+1])
+}
@@ -0,0 +1,121 @@
+// Package segtree provides segment trees, which answer range queries over
+// a sequence of elements combined with an associative operation, such as
+// sum, minimum or maximum, in O(log n) time.
+package segtree
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/linhns/gocontainers/vector"
+)
+
+// Monoid describes how elements of a segment tree are combined.
+//
+// Combine must be associative, and Identity must satisfy
+// Combine(Identity, v) == Combine(v, Identity) == v for every v. Combine
+// need not be commutative.
+type Monoid[T any] struct {
+	Combine  func(a, b T) T
+	Identity T
+}
+
+// SegTree is a segment tree over a fixed number of elements supporting
+// point updates and range queries.
+type SegTree[T any] struct {
+	// tree holds the leaves in tree[n:] and the combination of the
+	// children 2i and 2i+1 of an inner node i in tree[i].
+	tree   []T
+	n      int
+	monoid Monoid[T]
+}
+
+// New creates a new [SegTree] with n elements, all set to the identity of
+// monoid.
+func New[T any](n int, monoid Monoid[T]) *SegTree[T] {
+	tree := make([]T, 2*n)
+	for i := range tree {
+		tree[i] = monoid.Identity
+	}
+	return &SegTree[T]{tree: tree, n: n, monoid: monoid}
+}
+
+// FromVector creates a new [SegTree] with the elements of v in O(n) time.
+func FromVector[T any](v *vector.Vector[T], monoid Monoid[T]) *SegTree[T] {
+	return Collect(v.Values(), monoid)
+}
+
+// Collect collects values from an iterator into a new [SegTree] in O(n)
+// time.
+func Collect[T any](seq iter.Seq[T], monoid Monoid[T]) *SegTree[T] {
+	leaves := slices.Collect(seq)
+	n := len(leaves)
+	t := &SegTree[T]{tree: append(make([]T, n, 2*n), leaves...), n: n, monoid: monoid}
+	for i := n - 1; i > 0; i-- {
+		t.pull(i)
+	}
+	return t
+}
+
+// Len returns the number of elements in the tree.
+func (t *SegTree[T]) Len() int {
+	return t.n
+}
+
+// Get returns the zero-indexed ith element of t, if any.
+func (t *SegTree[T]) Get(i int) (T, bool) {
+	if i < 0 || i >= t.n {
+		var zero T
+		return zero, false
+	}
+	return t.tree[t.n+i], true
+}
+
+// Set sets the zero-indexed ith element of t to value in O(log n) time.
+//
+// Set panics if i is negative or greater than or equal to the length of t.
+func (t *SegTree[T]) Set(i int, value T) {
+	if i < 0 || i >= t.n {
+		panic("segtree.Set: index out of range")
+	}
+	i += t.n
+	t.tree[i] = value
+	for i > 1 {
+		i /= 2
+		t.pull(i)
+	}
+}
+
+// Query returns the combination of the elements with indices in [l, r)
+// in O(log n) time, or the identity if the range is empty.
+//
+// Query panics if l < 0, r > Len or l > r.
+func (t *SegTree[T]) Query(l, r int) T {
+	if l < 0 || r > t.n || l > r {
+		panic("segtree.Query: invalid range")
+	}
+	// Combine from both ends towards the middle, keeping the two partial
+	// results apart so that the order of the elements is preserved.
+	left, right := t.monoid.Identity, t.monoid.Identity
+	for l, r = l+t.n, r+t.n; l < r; l, r = l/2, r/2 {
+		if l%2 == 1 {
+			left = t.monoid.Combine(left, t.tree[l])
+			l++
+		}
+		if r%2 == 1 {
+			r--
+			right = t.monoid.Combine(t.tree[r], right)
+		}
+	}
+	return t.monoid.Combine(left, right)
+}
+
+// Values returns an iterator over the elements of the tree.
+func (t *SegTree[T]) Values() iter.Seq[T] {
+	return slices.Values(t.tree[t.n:])
+}
+
+// pull recomputes the inner node i from its children.
+func (t *SegTree[T]) pull(i int) {
+	t.tree[i] = t.monoid.Combine(t.tree[2*i], t.tree[2*i+1])
+}
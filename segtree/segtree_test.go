@@ -0,0 +1,210 @@
+package segtree_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/linhns/gocontainers/segtree"
+	"github.com/linhns/gocontainers/vector"
+)
+
+var (
+	sum    = segtree.Monoid[int]{Combine: func(a, b int) int { return a + b }, Identity: 0}
+	minInt = segtree.Monoid[int]{Combine: func(a, b int) int { return min(a, b) }, Identity: math.MaxInt}
+	concat = segtree.Monoid[string]{Combine: func(a, b string) string { return a + b }}
+)
+
+func TestSegTree(t *testing.T) {
+	st := segtree.FromVector(vector.Of(5, 3, 8, 1, 9, 2), sum)
+	assert.Equal(t, 6, st.Len())
+	assert.Equal(t, 28, st.Query(0, 6))
+	assert.Equal(t, 12, st.Query(1, 4))
+	assert.Equal(t, 0, st.Query(2, 2))
+
+	st.Set(3, 10)
+	assert.Equal(t, 21, st.Query(1, 4))
+	v, ok := st.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	_, ok = st.Get(6)
+	assert.False(t, ok)
+	assert.Equal(t, []int{5, 3, 8, 10, 9, 2}, slices.Collect(st.Values()))
+
+	mins := segtree.New(4, minInt)
+	assert.Equal(t, math.MaxInt, mins.Query(0, 4))
+	mins.Set(2, 7)
+	mins.Set(0, 9)
+	assert.Equal(t, 7, mins.Query(0, 4))
+	assert.Equal(t, 9, mins.Query(0, 2))
+}
+
+func TestSegTreeEmpty(t *testing.T) {
+	st := segtree.Collect(slices.Values([]int(nil)), sum)
+	assert.Equal(t, 0, st.Len())
+	assert.Equal(t, 0, st.Query(0, 0))
+	assert.Empty(t, slices.Collect(st.Values()))
+
+	lt := segtree.NewLazy(0, sum, addToSum)
+	assert.Equal(t, 0, lt.Query(0, 0))
+	lt.Apply(0, 0, 1)
+	assert.Empty(t, slices.Collect(lt.Values()))
+}
+
+func TestSegTreePanics(t *testing.T) {
+	st := segtree.New(3, sum)
+	assert.PanicsWithValue(t, "segtree.Set: index out of range", func() { st.Set(3, 1) })
+	assert.PanicsWithValue(t, "segtree.Query: invalid range", func() { st.Query(2, 1) })
+	assert.PanicsWithValue(t, "segtree.Query: invalid range", func() { st.Query(0, 4) })
+
+	lt := segtree.NewLazy(3, sum, addToSum)
+	assert.PanicsWithValue(t, "segtree.Set: index out of range", func() { lt.Set(-1, 1) })
+	assert.PanicsWithValue(t, "segtree.Query: invalid range", func() { lt.Query(-1, 1) })
+	assert.PanicsWithValue(t, "segtree.Apply: invalid range", func() { lt.Apply(1, 4, 1) })
+}
+
+// TestSegTreeOrder checks that queries combine elements in order, using
+// string concatenation, which is not commutative.
+func TestSegTreeOrder(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for n := range 20 {
+		letters := make([]string, n)
+		for i := range letters {
+			letters[i] = string(rune('a' + i))
+		}
+		st := segtree.Collect(slices.Values(letters), concat)
+		for range 50 {
+			i, v := r.IntN(n+1), string(rune('A'+r.IntN(26)))
+			if i < n {
+				st.Set(i, v)
+				letters[i] = v
+			}
+			l := r.IntN(n + 1)
+			r := l + r.IntN(n-l+1)
+			assert.Equal(t, strings.Join(letters[l:r], ""), st.Query(l, r))
+		}
+	}
+}
+
+// addToSum adds a value to every element of a range of a tree of sums.
+var addToSum = segtree.Action[int, int]{
+	Apply:   func(f, v, n int) int { return v + f*n },
+	Compose: func(f, g int) int { return f + g },
+}
+
+// assignMin assigns a value to every element of a range of a tree of
+// minimums. A nil update leaves the elements unchanged.
+var assignMin = segtree.Action[int, *int]{
+	Apply: func(f *int, v, n int) int {
+		if f == nil {
+			return v
+		}
+		return *f
+	},
+	Compose: func(f, g *int) *int {
+		if f == nil {
+			return g
+		}
+		return f
+	},
+}
+
+func TestLazySegTree(t *testing.T) {
+	lt := segtree.LazyFromVector(vector.Of(1, 2, 3, 4, 5), sum, addToSum)
+	assert.Equal(t, 15, lt.Query(0, 5))
+
+	lt.Apply(1, 4, 10)
+	assert.Equal(t, 45, lt.Query(0, 5))
+	assert.Equal(t, 27, lt.Query(2, 4))
+	v, _ := lt.Get(2)
+	assert.Equal(t, 13, v)
+
+	lt.Set(2, 0)
+	assert.Equal(t, 32, lt.Query(0, 5))
+	assert.Equal(t, []int{1, 12, 0, 14, 5}, slices.Collect(lt.Values()))
+}
+
+func TestLazySegTreeModel(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for n := 1; n <= 40; n++ {
+		model := make([]int, n)
+		for i := range model {
+			model[i] = r.IntN(100)
+		}
+		sums := segtree.CollectLazy(slices.Values(model), sum, addToSum)
+		mins := segtree.CollectLazy(slices.Values(model), minInt, assignMin)
+		minModel := slices.Clone(model)
+
+		for range 200 {
+			l := r.IntN(n + 1)
+			h := l + r.IntN(n-l+1)
+			v := r.IntN(100)
+			switch r.IntN(4) {
+			case 0:
+				sums.Apply(l, h, v)
+				mins.Apply(l, h, &v)
+				for i := l; i < h; i++ {
+					model[i] += v
+					minModel[i] = v
+				}
+			case 1:
+				if l < n {
+					sums.Set(l, v)
+					mins.Set(l, v)
+					model[l], minModel[l] = v, v
+				}
+			case 2:
+				if l < n {
+					got, ok := sums.Get(l)
+					assert.True(t, ok)
+					assert.Equal(t, model[l], got)
+				}
+			case 3:
+				want := 0
+				for _, x := range model[l:h] {
+					want += x
+				}
+				assert.Equal(t, want, sums.Query(l, h))
+				wantMin := math.MaxInt
+				if l < h {
+					wantMin = slices.Min(minModel[l:h])
+				}
+				assert.Equal(t, wantMin, mins.Query(l, h))
+			}
+		}
+		assert.Equal(t, model, slices.Collect(sums.Values()))
+		assert.Equal(t, minModel, slices.Collect(mins.Values()))
+	}
+}
+
+func BenchmarkQuery(b *testing.B) {
+	const n = 1 << 16
+	st := segtree.Collect(func(yield func(int) bool) {
+		for i := range n {
+			if !yield(i) {
+				return
+			}
+		}
+	}, sum)
+	lt := segtree.NewLazy(n, sum, addToSum)
+	r := rand.New(rand.NewPCG(1, 2))
+
+	b.Run("SegTree", func(b *testing.B) {
+		for range b.N {
+			l := r.IntN(n)
+			st.Set(l, l)
+			st.Query(l, l+r.IntN(n-l))
+		}
+	})
+	b.Run("LazySegTree", func(b *testing.B) {
+		for range b.N {
+			l := r.IntN(n)
+			lt.Apply(l, l+r.IntN(n-l), 1)
+			lt.Query(l, l+r.IntN(n-l))
+		}
+	})
+}
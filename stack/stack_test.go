@@ -1,8 +1,10 @@
 package stack_test
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/linhns/gocontainers/containertest"
 	"github.com/linhns/gocontainers/stack"
 	"github.com/stretchr/testify/assert"
 )
@@ -33,3 +35,37 @@ func TestStack(t *testing.T) {
 	_, ok = s.Top()
 	assert.False(t, ok)
 }
+
+func TestStackCollect(t *testing.T) {
+	s := stack.Collect(slices.Values([]int{1, 2, 3}))
+	assert.Equal(t, 3, s.Len())
+
+	for want := 3; want >= 1; want-- {
+		val, ok := s.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, want, val)
+	}
+}
+
+func TestStackClear(t *testing.T) {
+	s := stack.Collect(slices.Values([]int{1, 2, 3}))
+	s.Clear()
+	assert.True(t, s.Empty())
+
+	s.Push(4)
+	val, ok := s.Top()
+	assert.True(t, ok)
+	assert.Equal(t, 4, val)
+}
+
+func TestConformance(t *testing.T) {
+	containertest.TestStack(t, stack.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestStackModel(t, stack.New[int])
+}
+
+func FuzzStack(f *testing.F) {
+	containertest.FuzzStack(f, stack.New[int])
+}
@@ -4,6 +4,8 @@ package vector
 import (
 	"iter"
 	"slices"
+
+	"github.com/linhns/gocontainers/container"
 )
 
 // Vector represent a growable collection of elements
@@ -12,6 +14,8 @@ type Vector[T any] struct {
 	data []T
 }
 
+var _ container.Sequence[int] = (*Vector[int])(nil)
+
 // New creates and initializes a new [Vector]
 func New[T any]() *Vector[T] {
 	return &Vector[T]{
@@ -3,6 +3,7 @@ package vector_test
 import (
 	"testing"
 
+	"github.com/linhns/gocontainers/containertest"
 	"github.com/linhns/gocontainers/vector"
 	"github.com/stretchr/testify/assert"
 )
@@ -386,3 +387,15 @@ func TestValuesCollectRoundtrip(t *testing.T) {
 	got := vector.Collect(want.Values())
 	assert.True(t, vector.Equal(got, want))
 }
+
+func TestConformance(t *testing.T) {
+	containertest.TestSequence(t, vector.New[int])
+}
+
+func TestModel(t *testing.T) {
+	containertest.TestSequenceModel(t, vector.New[int])
+}
+
+func FuzzVector(f *testing.F) {
+	containertest.FuzzSequence(f, vector.New[int])
+}